@@ -0,0 +1,274 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+const (
+	providerEnvVar     = "EDGEX_CONFIG_PROVIDER"
+	defaultProviderUrl = "keeper.http://localhost:59890"
+	defaultBasePath    = "edgex/v4"
+	defaultBusProtocol = "tcp"
+)
+
+// The exit codes of the command
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errDifferences is returned by diff when the configurations differ, to exit with exitError without printing an error
+var errDifferences = errors.New("the configurations differ")
+
+// errUsage is returned when the command line is invalid, after printing the usage
+var errUsage = errors.New("invalid usage")
+
+// app is the command line tool, whose dependencies are replaced by the tests
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// newClient creates the client of the Configuration service
+	newClient func(config types.ServiceConfig) (configuration.Client, error)
+	// newMessageClient creates the connected message client the watch command receives the changes with
+	newMessageClient func(busUrl string) (messaging.MessageClient, error)
+
+	// the global flags
+	providerUrl string
+	basePath    string
+	token       string
+	busUrl      string
+	output      string
+}
+
+func newApp(stdin io.Reader, stdout io.Writer, stderr io.Writer) *app {
+	return &app{
+		stdin:            stdin,
+		stdout:           stdout,
+		stderr:           stderr,
+		newClient:        configuration.NewConfigurationClient,
+		newMessageClient: newMessageBusClient,
+	}
+}
+
+// command is a sub-command of the tool, whose run function receives the arguments following its flags
+type command struct {
+	usage       string
+	description string
+	flags       func(flags *flag.FlagSet)
+	run         func(ctx context.Context, client configuration.Client, args []string) error
+}
+
+func (a *app) commands() map[string]*command {
+	var deep bool
+	var overwrite bool
+	return map[string]*command{
+		"get": {
+			usage:       "get <key>",
+			description: "print the value of key",
+			run:         a.get,
+		},
+		"put": {
+			usage:       "put <key> <value>",
+			description: "set the value of key",
+			run:         a.put,
+		},
+		"keys": {
+			usage:       "keys [prefix]",
+			description: "list the keys under prefix",
+			run:         a.keys,
+		},
+		"export": {
+			usage:       "export [file]",
+			description: "export the configuration to file, or to the standard output",
+			run:         a.export,
+		},
+		"import": {
+			usage:       "import [-overwrite=false] <file>",
+			description: "import the configuration from a JSON or YAML file, \"-\" for the standard input",
+			flags: func(flags *flag.FlagSet) {
+				flags.BoolVar(&overwrite, "overwrite", true, "overwrite the existing values")
+			},
+			run: func(ctx context.Context, client configuration.Client, args []string) error {
+				return a.importFile(client, args, overwrite)
+			},
+		},
+		"diff": {
+			usage:       "diff <file>",
+			description: "compare the configuration with a JSON or YAML file, exits with 1 if they differ",
+			run:         a.diff,
+		},
+		"watch": {
+			usage:       "watch [key]",
+			description: "print the configuration under key every time it changes, until interrupted",
+			run:         a.watch,
+		},
+		"delete": {
+			usage:       "delete [-r] <key>",
+			description: "delete key, along with the keys under it with -r",
+			flags: func(flags *flag.FlagSet) {
+				flags.BoolVar(&deep, "r", false, "delete the keys under key too")
+			},
+			run: func(ctx context.Context, client configuration.Client, args []string) error {
+				return a.delete(client, args, deep)
+			},
+		},
+	}
+}
+
+// run runs the command line and returns the exit code
+func (a *app) run(ctx context.Context, arguments []string) int {
+	commands := a.commands()
+
+	providerUrl := os.Getenv(providerEnvVar)
+	if providerUrl == "" {
+		providerUrl = defaultProviderUrl
+	}
+	flags := flag.NewFlagSet("edgex-config", flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	flags.StringVar(&a.providerUrl, "url", providerUrl, "the URL of the Configuration service, defaults to $"+providerEnvVar)
+	flags.StringVar(&a.basePath, "base-path", defaultBasePath, "the base path the keys are relative to")
+	flags.StringVar(&a.token, "token", "", "the JWT to authenticate to the Configuration service with")
+	flags.StringVar(&a.busUrl, "message-bus", "", "the URL of the message bus the watch command receives the changes from, i.e. mqtt.tcp://localhost:1883")
+	flags.StringVar(&a.output, "o", formatTable, "the output format: table, json or yaml")
+	flags.Usage = func() { a.usage(flags, commands) }
+	if err := flags.Parse(arguments); err != nil {
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	cmd, found := commands[flags.Arg(0)]
+	if !found {
+		fmt.Fprintf(a.stderr, "unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return exitUsage
+	}
+	if !isFormat(a.output) {
+		fmt.Fprintf(a.stderr, "unknown output format %q\n", a.output)
+		return exitUsage
+	}
+
+	cmdFlags := flag.NewFlagSet(flags.Arg(0), flag.ContinueOnError)
+	cmdFlags.SetOutput(a.stderr)
+	cmdFlags.Usage = func() { fmt.Fprintf(a.stderr, "usage: edgex-config [flags] %s\n", cmd.usage) }
+	if cmd.flags != nil {
+		cmd.flags(cmdFlags)
+	}
+	if err := cmdFlags.Parse(flags.Args()[1:]); err != nil {
+		return exitUsage
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		fmt.Fprintln(a.stderr, err)
+		return exitError
+	}
+
+	err = cmd.run(ctx, client, cmdFlags.Args())
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		cmdFlags.Usage()
+		return exitUsage
+	case errors.Is(err, errDifferences):
+		return exitError
+	default:
+		fmt.Fprintln(a.stderr, err)
+		return exitError
+	}
+}
+
+func (a *app) usage(flags *flag.FlagSet, commands map[string]*command) {
+	fmt.Fprintln(a.stderr, "usage: edgex-config [flags] <command> [arguments]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	for _, name := range sortedKeys(commands) {
+		fmt.Fprintf(a.stderr, "  %-34s %s\n", commands[name].usage, commands[name].description)
+	}
+	fmt.Fprintln(a.stderr, "\nflags:")
+	flags.PrintDefaults()
+}
+
+// connect creates the client of the Configuration service from the global flags
+func (a *app) connect() (configuration.Client, error) {
+	config := types.ServiceConfig{
+		BasePath:     a.basePath,
+		AuthInjector: bearerAuthInjector{token: a.token},
+	}
+	if err := config.PopulateFromUrl(a.providerUrl); err != nil {
+		return nil, err
+	}
+	return a.newClient(config)
+}
+
+// bearerAuthInjector authenticates the requests with a JWT, if any
+type bearerAuthInjector struct {
+	token string
+}
+
+func (b bearerAuthInjector) AddAuthenticationData(req *http.Request) error {
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	return nil
+}
+
+func (b bearerAuthInjector) RoundTripper() http.RoundTripper {
+	return nil
+}
+
+// newMessageBusClient connects to the message bus at busUrl, whose format is <type>[.<protocol>]://<host>:<port>,
+// i.e. mqtt.tcp://localhost:1883 or nats-core.tcp://localhost:4222
+func newMessageBusClient(busUrl string) (messaging.MessageClient, error) {
+	if busUrl == "" {
+		return nil, errors.New("the watch command requires the -message-bus flag")
+	}
+	parsedUrl, err := url.Parse(busUrl)
+	if err != nil {
+		return nil, fmt.Errorf("the format of the message bus URL is incorrect (%s): %v", busUrl, err)
+	}
+	port, err := strconv.Atoi(parsedUrl.Port())
+	if err != nil {
+		return nil, fmt.Errorf("the port from the message bus URL is incorrect (%s): %v", busUrl, err)
+	}
+	busType, protocol, found := strings.Cut(parsedUrl.Scheme, ".")
+	if !found {
+		protocol = defaultBusProtocol
+	}
+
+	client, err := messaging.NewMessageClient(msgTypes.MessageBusConfig{
+		Broker: msgTypes.HostInfo{Host: parsedUrl.Hostname(), Port: port, Protocol: protocol},
+		Type:   busType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the message bus client: %v", err)
+	}
+	if err = client.Connect(); err != nil {
+		return nil, fmt.Errorf("unable to connect to the message bus at %s: %v", busUrl, err)
+	}
+	return client, nil
+}
@@ -0,0 +1,263 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/keepertest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testBasePath = "edgex/v4/core-data"
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of the watch command
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+type testKeeper struct {
+	server *keepertest.Server
+	bus    *keepertest.MessageBus
+	url    string
+}
+
+func startKeeper(t *testing.T) *testKeeper {
+	keeper := &testKeeper{
+		server: keepertest.NewServer(),
+		bus:    keepertest.NewMessageBus(),
+	}
+	keeper.server.SetMessageClient(keeper.bus.NewClient())
+	httpServer := keeper.server.Start()
+	t.Cleanup(httpServer.Close)
+	keeper.url = "keeper." + httpServer.URL
+	return keeper
+}
+
+// newApp creates the command line tool whose watch command receives the changes from the message bus of keeper
+func (k *testKeeper) newApp(stdin string) (*app, *syncBuffer, *syncBuffer) {
+	stdout := &syncBuffer{}
+	stderr := &syncBuffer{}
+	a := newApp(strings.NewReader(stdin), stdout, stderr)
+	a.newMessageClient = func(string) (messaging.MessageClient, error) {
+		return k.bus.NewClient(), nil
+	}
+	return a, stdout, stderr
+}
+
+// run runs the command line against keeper and returns the exit code, the standard output and the standard error
+func (k *testKeeper) run(stdin string, args ...string) (int, string, string) {
+	a, stdout, stderr := k.newApp(stdin)
+	code := a.run(context.Background(), append([]string{"-url", k.url, "-base-path", testBasePath}, args...))
+	return code, stdout.String(), stderr.String()
+}
+
+func TestGetPutDelete(t *testing.T) {
+	keeper := startKeeper(t)
+
+	code, _, stderr := keeper.run("", "get", "Writable/LogLevel")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "not found")
+
+	code, _, _ = keeper.run("", "put", "Writable/LogLevel", "INFO")
+	require.Equal(t, exitOK, code)
+	kv, found := keeper.server.Get(testBasePath + "/Writable/LogLevel")
+	require.True(t, found)
+	assert.Equal(t, "INFO", kv.Value)
+
+	code, stdout, _ := keeper.run("", "get", "Writable/LogLevel")
+	require.Equal(t, exitOK, code)
+	assert.Equal(t, "INFO\n", stdout)
+
+	code, stdout, _ = keeper.run("", "-o", "json", "get", "Writable/LogLevel")
+	require.Equal(t, exitOK, code)
+	assert.JSONEq(t, `{"key": "Writable/LogLevel", "value": "INFO"}`, stdout)
+
+	code, stdout, _ = keeper.run("", "-o", "yaml", "get", "Writable/LogLevel")
+	require.Equal(t, exitOK, code)
+	assert.Equal(t, "key: Writable/LogLevel\nvalue: INFO\n", stdout)
+
+	code, _, _ = keeper.run("", "put", "Writable/Port", "8080")
+	require.Equal(t, exitOK, code)
+	code, _, _ = keeper.run("", "delete", "Writable/LogLevel")
+	require.Equal(t, exitOK, code)
+	assert.Equal(t, []string{testBasePath + "/Writable/Port"}, keeper.server.Keys())
+
+	code, _, _ = keeper.run("", "delete", "-r", "Writable")
+	require.Equal(t, exitOK, code)
+	assert.Empty(t, keeper.server.Keys())
+}
+
+func TestKeys(t *testing.T) {
+	keeper := startKeeper(t)
+	code, _, _ := keeper.run(`{"Writable": {"LogLevel": "INFO", "Port": 8080}, "Service": {"Host": "localhost"}}`, "import", "-")
+	require.Equal(t, exitOK, code)
+
+	code, stdout, _ := keeper.run("", "keys")
+	require.Equal(t, exitOK, code)
+	assert.Equal(t, "Service/Host\nWritable/LogLevel\nWritable/Port\n", stdout)
+
+	code, stdout, _ = keeper.run("", "-o", "json", "keys", "Writable")
+	require.Equal(t, exitOK, code)
+	assert.JSONEq(t, `["Writable/LogLevel", "Writable/Port"]`, stdout)
+}
+
+func TestExportImport(t *testing.T) {
+	keeper := startKeeper(t)
+	input := "Writable:\n  LogLevel: INFO\n  Hosts:\n    - a\n    - b\nService:\n  Port: 59880\n"
+	code, _, stderr := keeper.run(input, "import", "-")
+	require.Equal(t, exitOK, code, stderr)
+
+	code, stdout, _ := keeper.run("", "export")
+	require.Equal(t, exitOK, code)
+	assert.Equal(t, "KEY                VALUE\nService/Port       59880\nWritable/Hosts/0   a\nWritable/Hosts/1   b\nWritable/LogLevel  INFO\n", stdout)
+
+	code, stdout, _ = keeper.run("", "-o", "json", "export")
+	require.Equal(t, exitOK, code)
+	assert.JSONEq(t, `{"Service": {"Port": "59880"}, "Writable": {"LogLevel": "INFO", "Hosts": {"0": "a", "1": "b"}}}`, stdout)
+
+	// the format of the exported file is given by its extension
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	code, _, _ = keeper.run("", "export", file)
+	require.Equal(t, exitOK, code)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var exported map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &exported))
+	assert.Equal(t, map[string]any{"Port": "59880"}, exported["Service"])
+
+	// the existing values are kept without overwrite
+	code, _, _ = keeper.run(`{"Writable": {"LogLevel": "DEBUG", "Port": 8080}}`, "import", "-overwrite=false", "-")
+	require.Equal(t, exitOK, code)
+	kv, _ := keeper.server.Get(testBasePath + "/Writable/LogLevel")
+	assert.Equal(t, "INFO", kv.Value)
+	kv, _ = keeper.server.Get(testBasePath + "/Writable/Port")
+	assert.Equal(t, "8080", kv.Value)
+
+	code, _, stderr = keeper.run("not: [valid", "import", "-")
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "unable to parse")
+}
+
+func TestExportEncrypted(t *testing.T) {
+	keeper := startKeeper(t)
+	encrypted := "enc:v1:key1:c2VjcmV0LXBhc3N3b3Jk"
+	code, _, stderr := keeper.run(`{"Writable": {"InsecureSecrets": {"DB": {"Password": "`+encrypted+`"}}}}`, "import", "-")
+	require.Equal(t, exitOK, code, stderr)
+
+	// the encrypted value is exported as stored, which the tool can't decrypt
+	code, stdout, stderr := keeper.run("", "-o", "json", "export")
+	require.Equal(t, exitOK, code, stderr)
+	assert.JSONEq(t, `{"Writable": {"InsecureSecrets": {"DB": {"Password": "`+encrypted+`"}}}}`, stdout)
+}
+
+func TestDiff(t *testing.T) {
+	keeper := startKeeper(t)
+	code, _, _ := keeper.run(`{"Writable": {"LogLevel": "INFO", "Port": 8080}, "Service": {"Host": "localhost"}}`, "import", "-")
+	require.Equal(t, exitOK, code)
+
+	file := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"Writable": {"LogLevel": "INFO", "Port": 8080}, "Service": {"Host": "localhost"}}`), 0600))
+	code, stdout, _ := keeper.run("", "diff", file)
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stdout)
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"Writable": {"LogLevel": "DEBUG", "Port": 8080}, "Clients": {"Host": "core-metadata"}}`), 0600))
+	code, stdout, stderr := keeper.run("", "diff", file)
+	assert.Equal(t, exitError, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "+Clients/Host: core-metadata\n-Service/Host: localhost\n-Writable/LogLevel: INFO\n+Writable/LogLevel: DEBUG\n", stdout)
+
+	code, stdout, _ = keeper.run("", "-o", "json", "diff", file)
+	assert.Equal(t, exitError, code)
+	var differences []difference
+	require.NoError(t, json.Unmarshal([]byte(stdout), &differences))
+	require.Len(t, differences, 3)
+	assert.Equal(t, "Service/Host", differences[1].Key)
+	assert.Equal(t, "localhost", *differences[1].Stored)
+	assert.Nil(t, differences[1].File)
+}
+
+func TestWatch(t *testing.T) {
+	keeper := startKeeper(t)
+	code, _, _ := keeper.run(`{"Writable": {"LogLevel": "INFO", "Port": 8080}}`, "import", "-")
+	require.Equal(t, exitOK, code)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a, stdout, stderr := keeper.newApp("")
+	done := make(chan int)
+	go func() {
+		done <- a.run(ctx, []string{"-url", keeper.url, "-base-path", testBasePath, "-o", "json", "watch", "Writable"})
+	}()
+
+	// the changes are put until the watch has subscribed
+	assert.Eventually(t, func() bool {
+		code, _, _ := keeper.run("", "put", "Writable/LogLevel", "DEBUG")
+		require.Equal(t, exitOK, code)
+		return strings.Contains(stdout.String(), `"LogLevel":"DEBUG"`)
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Contains(t, stdout.String(), `{"LogLevel":"DEBUG","Port":"8080"}`)
+
+	cancel()
+	select {
+	case code = <-done:
+		assert.Equal(t, exitOK, code)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "watch not stopped")
+	}
+	assert.Empty(t, stderr.String())
+}
+
+func TestUsage(t *testing.T) {
+	keeper := startKeeper(t)
+
+	code, _, stderr := keeper.run("")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "usage: edgex-config")
+
+	code, _, stderr = keeper.run("", "unknown")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, `unknown command "unknown"`)
+
+	code, _, stderr = keeper.run("", "put", "Writable/LogLevel")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "usage: edgex-config [flags] put <key> <value>")
+
+	code, _, stderr = keeper.run("", "-o", "xml", "keys")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, `unknown output format "xml"`)
+
+	a, _, output := keeper.newApp("")
+	code = a.run(context.Background(), []string{"-url", "keeper.http://localhost", "keys"})
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, output.String(), "port from Provider URL is incorrect")
+}
@@ -0,0 +1,278 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const stdinFile = "-"
+
+// keyValue is the output of get
+type keyValue struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// difference is a key whose value differs between the Configuration service and the file compared by diff,
+// Stored or File is nil if the key is missing from it
+type difference struct {
+	Key    string  `json:"key" yaml:"key"`
+	Stored *string `json:"stored,omitempty" yaml:"stored,omitempty"`
+	File   *string `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+func (a *app) get(_ context.Context, client configuration.Client, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	value, err := client.GetConfigurationValue(args[0])
+	if err != nil {
+		return err
+	}
+	if a.output == formatTable {
+		// the raw value is easier to use in scripts
+		_, err = fmt.Fprintln(a.stdout, string(value))
+		return err
+	}
+	return writeData(a.stdout, a.output, keyValue{Key: args[0], Value: string(value)})
+}
+
+func (a *app) put(_ context.Context, client configuration.Client, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	return client.PutConfigurationValue(args[0], []byte(args[1]))
+}
+
+func (a *app) keys(_ context.Context, client configuration.Client, args []string) error {
+	if len(args) > 1 {
+		return errUsage
+	}
+	prefix := ""
+	if len(args) == 1 {
+		prefix = args[0]
+	}
+	keys, err := client.GetConfigurationKeys(prefix)
+	if err != nil {
+		return err
+	}
+	relativeKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		relativeKeys = append(relativeKeys, a.relativeKey(key))
+	}
+	return writeList(a.stdout, a.output, sortedStrings(relativeKeys))
+}
+
+func (a *app) export(_ context.Context, client configuration.Client, args []string) error {
+	if len(args) > 1 {
+		return errUsage
+	}
+	values, err := a.storedValues(client)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return writeValues(a.stdout, a.output, values)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	err = writeValues(file, fileFormat(args[0], a.output), values)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (a *app) importFile(client configuration.Client, args []string, overwrite bool) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	config, err := a.readFile(args[0])
+	if err != nil {
+		return err
+	}
+	return client.PutConfigurationMap(config, overwrite)
+}
+
+func (a *app) diff(_ context.Context, client configuration.Client, args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	config, err := a.readFile(args[0])
+	if err != nil {
+		return err
+	}
+	fileValues := make(map[string]string)
+	flatten("", config, fileValues)
+
+	storedValues, err := a.storedValues(client)
+	if err != nil && !errors.Is(err, types.ErrNotFound) {
+		return err
+	}
+
+	var differences []difference
+	for _, key := range sortedKeys(mergeKeys(storedValues, fileValues)) {
+		stored, inStore := storedValues[key]
+		file, inFile := fileValues[key]
+		if inStore && inFile && stored == file {
+			continue
+		}
+		d := difference{Key: key}
+		if inStore {
+			d.Stored = &stored
+		}
+		if inFile {
+			d.File = &file
+		}
+		differences = append(differences, d)
+	}
+
+	if err = writeDifferences(a.stdout, a.output, differences); err != nil {
+		return err
+	}
+	if len(differences) > 0 {
+		return errDifferences
+	}
+	return nil
+}
+
+func (a *app) watch(ctx context.Context, client configuration.Client, args []string) error {
+	if len(args) > 1 {
+		return errUsage
+	}
+	key := ""
+	if len(args) == 1 {
+		key = args[0]
+	}
+	messageClient, err := a.newMessageClient(a.busUrl)
+	if err != nil {
+		return err
+	}
+
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &map[string]any{}, key, func() messaging.MessageClient {
+		return messageClient
+	})
+	defer client.StopWatching()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-errorChannel:
+			fmt.Fprintln(a.stderr, err)
+		case update := <-updateChannel:
+			// the first update only notifies that the watch started
+			config, ok := update.(*map[string]any)
+			if !ok {
+				continue
+			}
+			values := make(map[string]string)
+			flatten("", *config, values)
+			if err = writeUpdate(a.stdout, a.output, values); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (a *app) delete(client configuration.Client, args []string, deep bool) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	deleter, ok := configuration.As[configuration.ValueDeleter](client)
+	if !ok {
+		return fmt.Errorf("unable to delete %s: %w", args[0], configuration.ErrNotSupported)
+	}
+	return deleter.DeleteConfigurationValue(args[0], deep)
+}
+
+// storedValues returns the values stored under the base path by their relative key. The values are retrieved as
+// stored rather than with GetConfiguration, so that their references aren't resolved and the sensitive values stay
+// encrypted.
+func (a *app) storedValues(client configuration.Client) (map[string]string, error) {
+	reader, ok := configuration.As[configuration.StoredEntryReader](client)
+	if !ok {
+		return nil, fmt.Errorf("unable to read the stored configuration: %w", configuration.ErrNotSupported)
+	}
+	entries, err := reader.GetStoredConfigurationEntries("")
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[a.relativeKey(entry.Key)] = string(entry.Value)
+	}
+	return values, nil
+}
+
+func (a *app) relativeKey(key string) string {
+	return strings.TrimPrefix(key, strings.TrimSuffix(a.basePath, "/")+"/")
+}
+
+// readFile reads the configuration from a JSON or YAML file, or from the standard input if name is "-"
+func (a *app) readFile(name string) (map[string]any, error) {
+	var data []byte
+	var err error
+	if name == stdinFile {
+		data, err = io.ReadAll(a.stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so both are parsed by the YAML decoder
+	var config map[string]any
+	if err = yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", name, err)
+	}
+	if len(config) == 0 {
+		return nil, fmt.Errorf("%s contains no configuration", name)
+	}
+	return config, nil
+}
+
+// fileFormat returns the format of the file exported to from its extension, or defaultFormat if unknown
+func fileFormat(name string, defaultFormat string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	default:
+		return defaultFormat
+	}
+}
+
+func mergeKeys(maps ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, m := range maps {
+		for key, value := range m {
+			merged[key] = value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command edgex-config inspects and edits the configuration stored in the Configuration service.
+//
+// Usage:
+//
+//	edgex-config [flags] <command> [arguments]
+//
+// The commands are:
+//
+//	get <key>              print the value of key
+//	put <key> <value>      set the value of key
+//	keys [prefix]          list the keys under prefix
+//	export [file]          export the configuration to file, or to the standard output
+//	import <file>          import the configuration from a JSON or YAML file, "-" for the standard input
+//	diff <file>            compare the configuration with a JSON or YAML file, exits with 1 if they differ
+//	watch [key]            print the configuration under key every time it changes, until interrupted
+//	delete [-r] <key>      delete key, along with the keys under it with -r
+//
+// The keys are relative to the base path. The values stored encrypted are exported and compared by diff as stored,
+// without being decrypted. The provider URL has the same format as the EDGEX_CONFIG_PROVIDER
+// environment variable used by the EdgeX services, i.e. keeper.http://localhost:59890, which is its default value.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := newApp(os.Stdin, os.Stdout, os.Stderr).run(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,191 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cast"
+	"gopkg.in/yaml.v3"
+)
+
+// The output formats
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func isFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatYAML
+}
+
+// writeData writes data as indented JSON or as YAML
+func writeData(w io.Writer, format string, data any) error {
+	if format == formatYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// writeTable writes the rows as aligned columns
+func writeTable(w io.Writer, header []string, rows [][]string) error {
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(writer, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// writeList writes a list of strings, one per line as a table
+func writeList(w io.Writer, format string, list []string) error {
+	if format != formatTable {
+		return writeData(w, format, list)
+	}
+	for _, item := range list {
+		if _, err := fmt.Fprintln(w, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeValues writes the values by key, which are nested by their path in JSON and YAML
+func writeValues(w io.Writer, format string, values map[string]string) error {
+	if format == formatTable {
+		return writeTable(w, []string{"KEY", "VALUE"}, valueRows(values))
+	}
+	nested, err := unflatten(values)
+	if err != nil {
+		return err
+	}
+	return writeData(w, format, nested)
+}
+
+// writeUpdate writes the values of a watched configuration, so that the successive updates can be told apart: one JSON
+// document per line, one YAML document each or tables separated by an empty line
+func writeUpdate(w io.Writer, format string, values map[string]string) error {
+	switch format {
+	case formatTable:
+		if err := writeTable(w, []string{"KEY", "VALUE"}, valueRows(values)); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(w)
+		return err
+	case formatYAML:
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		return writeValues(w, format, values)
+	default:
+		nested, err := unflatten(values)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(w).Encode(nested)
+	}
+}
+
+// writeDifferences writes the differences found by diff, in the unified diff style as a table
+func writeDifferences(w io.Writer, format string, differences []difference) error {
+	if format != formatTable {
+		if differences == nil {
+			differences = []difference{}
+		}
+		return writeData(w, format, differences)
+	}
+	for _, d := range differences {
+		if d.Stored != nil {
+			if _, err := fmt.Fprintf(w, "-%s: %s\n", d.Key, *d.Stored); err != nil {
+				return err
+			}
+		}
+		if d.File != nil {
+			if _, err := fmt.Fprintf(w, "+%s: %s\n", d.Key, *d.File); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func valueRows(values map[string]string) [][]string {
+	rows := make([][]string, 0, len(values))
+	for _, key := range sortedKeys(values) {
+		rows = append(rows, []string{key, values[key]})
+	}
+	return rows
+}
+
+// flatten converts the maps and slices of value to the string values of their leaves by key path,
+// the same way the Configuration service stores them
+func flatten(key string, value any, values map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, item := range v {
+			flatten(path.Join(key, name), item, values)
+		}
+	case []any:
+		for index, item := range v {
+			flatten(path.Join(key, strconv.Itoa(index)), item, values)
+		}
+	default:
+		values[key] = cast.ToString(v)
+	}
+}
+
+// unflatten nests the values by their key path
+func unflatten(values map[string]string) (map[string]any, error) {
+	nested := make(map[string]any)
+	for _, key := range sortedKeys(values) {
+		m := nested
+		names := strings.Split(key, "/")
+		for _, name := range names[:len(names)-1] {
+			child, found := m[name]
+			if !found {
+				child = make(map[string]any)
+				m[name] = child
+			}
+			childMap, ok := child.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s is both a value and the parent of %s", name, key)
+			}
+			m = childMap
+		}
+		m[names[len(names)-1]] = values[key]
+	}
+	return nested, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return sortedStrings(keys)
+}
+
+func sortedStrings(list []string) []string {
+	slices.Sort(list)
+	return list
+}
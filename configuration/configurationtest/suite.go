@@ -0,0 +1,515 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configurationtest provides the conformance test suite of the configuration.Client implementations,
+// so that any configuration provider can prove it is compatible with the keeper provider.
+package configurationtest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// watchTimeout is how long the watch tests wait for an update from WatchForChanges
+const watchTimeout = 5 * time.Second
+
+// ClientFactory creates the Client under test with its configuration stored under basePath, which is unique to each
+// test. It also returns the callback to pass to WatchForChanges, or nil to skip the watch tests if the provider has
+// no message bus on which the changes are published.
+type ClientFactory func(t *testing.T, basePath string) (configuration.Client, func() messaging.MessageClient)
+
+// WritableInfo is the writable section of the configuration used by the suite
+type WritableInfo struct {
+	LogLevel string
+	Interval int
+}
+
+// ServiceInfo is the service section of the configuration used by the suite
+type ServiceInfo struct {
+	Host    string
+	Port    int
+	Timeout float64
+	Secure  bool
+}
+
+// Config is the configuration the suite puts into and gets from the provider
+type Config struct {
+	Writable WritableInfo
+	Service  ServiceInfo
+}
+
+func newConfig() Config {
+	return Config{
+		Writable: WritableInfo{
+			LogLevel: "INFO",
+			Interval: 30,
+		},
+		Service: ServiceInfo{
+			Host:    "localhost",
+			Port:    59880,
+			Timeout: 2.5,
+			Secure:  true,
+		},
+	}
+}
+
+type clientSuite struct {
+	factory ClientFactory
+	prefix  string
+	count   atomic.Int32
+}
+
+// RunClientSuite runs the conformance tests of the Client created by factory as subtests of t. It exercises every
+// configuration.Client method and the optional features implemented, the overwrite semantics of the puts, the key listing, the decoding of the
+// configuration put with PutConfiguration, the error categories and, if supported, the watch for changes.
+func RunClientSuite(t *testing.T, factory ClientFactory) {
+	suite := &clientSuite{
+		factory: factory,
+		prefix:  fmt.Sprintf("conformance-%d", time.Now().UnixNano()),
+	}
+
+	t.Run("HasConfiguration", suite.testHasConfiguration)
+	t.Run("HasSubConfiguration", suite.testHasSubConfiguration)
+	t.Run("PutConfigurationOverwrite", suite.testPutConfigurationOverwrite)
+	t.Run("PutConfigurationMapOverwrite", suite.testPutConfigurationMapOverwrite)
+	t.Run("GetConfigurationRoundTrip", suite.testGetConfigurationRoundTrip)
+	t.Run("GetConfigurationNotFound", suite.testGetConfigurationNotFound)
+	t.Run("GetConfigurationDecodeError", suite.testGetConfigurationDecodeError)
+	t.Run("ConfigurationValues", suite.testConfigurationValues)
+	t.Run("GetConfigurationKeys", suite.testGetConfigurationKeys)
+	t.Run("FullPath", suite.testFullPath)
+	t.Run("DeleteConfigurationValue", suite.testDeleteConfigurationValue)
+	t.Run("CheckAndPutConfigurationValue", suite.testCheckAndPutConfigurationValue)
+	t.Run("GetConfigurationEntries", suite.testGetConfigurationEntries)
+	t.Run("Availability", suite.testAvailability)
+	t.Run("WatchForChanges", suite.testWatchForChanges)
+	t.Run("WatchForChangesByFullPath", suite.testWatchForChangesByFullPath)
+	t.Run("OnChange", suite.testOnChange)
+}
+
+// newClient creates a client whose base path isn't a prefix of the base path of any other test
+func (s *clientSuite) newClient(t *testing.T) (configuration.Client, string, func() messaging.MessageClient) {
+	basePath := path.Join(s.prefix, fmt.Sprintf("%03d", s.count.Add(1)))
+	client, getMsgClientCb := s.factory(t, basePath)
+	require.NotNil(t, client, "the factory returned no client")
+	return client, basePath, getMsgClientCb
+}
+
+func (s *clientSuite) testHasConfiguration(t *testing.T) {
+	client, _, _ := s.newClient(t)
+
+	exists, err := client.HasConfiguration()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+	exists, err = client.HasConfiguration()
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func (s *clientSuite) testHasSubConfiguration(t *testing.T) {
+	client, _, _ := s.newClient(t)
+
+	exists, err := client.HasSubConfiguration("Writable")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+	exists, err = client.HasSubConfiguration("Writable")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = client.HasSubConfiguration("Missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func (s *clientSuite) testPutConfigurationOverwrite(t *testing.T) {
+	client, _, _ := s.newClient(t)
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+
+	// only the missing keys are created without overwrite
+	expected := newConfig()
+	require.NoError(t, client.PutConfiguration(expected, false))
+	expected.Writable.LogLevel = "DEBUG"
+	actual, err := client.GetConfiguration(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, *actual.(*Config))
+
+	modified := newConfig()
+	modified.Writable.LogLevel = "ERROR"
+	modified.Service.Port = 59881
+	require.NoError(t, client.PutConfiguration(modified, false))
+	actual, err = client.GetConfiguration(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, *actual.(*Config))
+
+	require.NoError(t, client.PutConfiguration(modified, true))
+	actual, err = client.GetConfiguration(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, modified, *actual.(*Config))
+}
+
+func (s *clientSuite) testPutConfigurationMapOverwrite(t *testing.T) {
+	client, _, _ := s.newClient(t)
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO"},
+		"Port":     59880,
+	}, true))
+
+	assertValue := func(name string, expected string) {
+		value, err := client.GetConfigurationValue(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(value), name)
+	}
+	assertValue("Writable/LogLevel", "INFO")
+	assertValue("Port", "59880")
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "DEBUG", "Interval": 30},
+		"Port":     59881,
+	}, false))
+	assertValue("Writable/LogLevel", "INFO")
+	assertValue("Writable/Interval", "30")
+	assertValue("Port", "59880")
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "DEBUG"},
+		"Port":     59881,
+	}, true))
+	assertValue("Writable/LogLevel", "DEBUG")
+	assertValue("Writable/Interval", "30")
+	assertValue("Port", "59881")
+}
+
+func (s *clientSuite) testGetConfigurationRoundTrip(t *testing.T) {
+	client, _, _ := s.newClient(t)
+	expected := newConfig()
+	require.NoError(t, client.PutConfiguration(expected, true))
+
+	target := &Config{}
+	actual, err := client.GetConfiguration(target)
+	require.NoError(t, err)
+	require.IsType(t, &Config{}, actual)
+	assert.Same(t, target, actual)
+	assert.Equal(t, expected, *target)
+}
+
+func (s *clientSuite) testGetConfigurationNotFound(t *testing.T) {
+	client, _, _ := s.newClient(t)
+
+	_, err := client.GetConfiguration(&Config{})
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func (s *clientSuite) testGetConfigurationDecodeError(t *testing.T) {
+	client, _, _ := s.newClient(t)
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+	require.NoError(t, client.PutConfigurationValue("Service/Port", []byte("abc")))
+
+	_, err := client.GetConfiguration(&Config{})
+	assert.ErrorIs(t, err, types.ErrDecode)
+}
+
+func (s *clientSuite) testConfigurationValues(t *testing.T) {
+	client, basePath, _ := s.newClient(t)
+
+	exists, err := client.ConfigurationValueExists("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = client.GetConfigurationValue("Writable/LogLevel")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	exists, err = client.ConfigurationValueExists("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("DEBUG"), value)
+	value, err = client.GetConfigurationValueByFullPath(path.Join(basePath, "Writable/LogLevel"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("DEBUG"), value)
+
+	// an existing value is overwritten
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("ERROR")))
+	value, err = client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ERROR"), value)
+}
+
+func (s *clientSuite) testGetConfigurationKeys(t *testing.T) {
+	client, basePath, _ := s.newClient(t)
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+
+	keys, err := client.GetConfigurationKeys("Writable")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		path.Join(basePath, "Writable/LogLevel"),
+		path.Join(basePath, "Writable/Interval"),
+	}, keys)
+
+	keys, err = client.GetConfigurationKeys("")
+	require.NoError(t, err)
+	assert.Len(t, keys, 6)
+}
+
+func (s *clientSuite) testFullPath(t *testing.T) {
+	common, commonPath, _ := s.newClient(t)
+	require.NoError(t, common.PutConfiguration(newConfig(), true))
+	client, _, _ := s.newClient(t)
+	reader := feature[configuration.FullPathReader](t, client)
+
+	// the configuration of another service is reachable given its full path
+	actual, err := reader.GetConfigurationByFullPath(&Config{}, commonPath)
+	require.NoError(t, err)
+	assert.Equal(t, newConfig(), *actual.(*Config))
+	writable, err := reader.GetConfigurationByFullPath(&WritableInfo{}, path.Join(commonPath, "Writable"))
+	require.NoError(t, err)
+	assert.Equal(t, newConfig().Writable, *writable.(*WritableInfo))
+
+	keys, err := reader.GetConfigurationKeysByFullPath(path.Join(commonPath, "Writable"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		path.Join(commonPath, "Writable/LogLevel"),
+		path.Join(commonPath, "Writable/Interval"),
+	}, keys)
+
+	_, err = reader.GetConfigurationByFullPath(&Config{}, path.Join(commonPath, "Missing"))
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	_, err = reader.GetConfigurationKeysByFullPath(path.Join(commonPath, "Missing"))
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func (s *clientSuite) testDeleteConfigurationValue(t *testing.T) {
+	client, _, _ := s.newClient(t)
+	deleter := feature[configuration.ValueDeleter](t, client)
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+
+	// only the value itself is deleted if not deep
+	err := deleter.DeleteConfigurationValue("Writable", false)
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	require.NoError(t, deleter.DeleteConfigurationValue("Writable/LogLevel", false))
+	exists, err := client.ConfigurationValueExists("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = client.ConfigurationValueExists("Writable/Interval")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, deleter.DeleteConfigurationValue("Service", true))
+	exists, err = client.HasSubConfiguration("Service")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = client.HasSubConfiguration("Writable")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	err = deleter.DeleteConfigurationValue("Missing", true)
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func (s *clientSuite) testCheckAndPutConfigurationValue(t *testing.T) {
+	client, basePath, _ := s.newClient(t)
+	writer := feature[configuration.ConditionalWriter](t, client)
+	reader := feature[configuration.EntryReader](t, client)
+
+	_, err := reader.GetConfigurationEntry("Writable/LogLevel")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	require.NoError(t, writer.CheckAndPutConfigurationValue("Writable/LogLevel", []byte("INFO"), types.ExpectAbsent()))
+	err = writer.CheckAndPutConfigurationValue("Writable/LogLevel", []byte("DEBUG"), types.ExpectAbsent())
+	assert.ErrorIs(t, err, types.ErrConflict)
+
+	entry, err := reader.GetConfigurationEntry("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(basePath, "Writable/LogLevel"), entry.Key)
+	assert.Equal(t, []byte("INFO"), entry.Value)
+	assert.False(t, entry.Modified.IsZero())
+
+	err = writer.CheckAndPutConfigurationValue("Writable/LogLevel", []byte("DEBUG"), types.ExpectValue([]byte("ERROR")))
+	assert.ErrorIs(t, err, types.ErrConflict)
+	require.NoError(t, writer.CheckAndPutConfigurationValue("Writable/LogLevel", []byte("DEBUG"), types.ExpectRevision(entry.Revision())))
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("DEBUG"), value)
+}
+
+func (s *clientSuite) testGetConfigurationEntries(t *testing.T) {
+	client, basePath, _ := s.newClient(t)
+	reader := feature[configuration.EntryReader](t, client)
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+
+	entries, err := reader.GetConfigurationEntries("Writable")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, path.Join(basePath, "Writable/Interval"), entries[0].Key)
+	assert.Equal(t, []byte("30"), entries[0].Value)
+	assert.Equal(t, path.Join(basePath, "Writable/LogLevel"), entries[1].Key)
+	assert.Equal(t, []byte("INFO"), entries[1].Value)
+
+	// the metadata has a millisecond precision
+	time.Sleep(2 * time.Millisecond)
+	since := time.Now()
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	entries, err = reader.GetConfigurationEntriesModifiedSince("", since)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, path.Join(basePath, "Writable/LogLevel"), entries[0].Key)
+	assert.Equal(t, []byte("DEBUG"), entries[0].Value)
+}
+
+func (s *clientSuite) testAvailability(t *testing.T) {
+	client, _, _ := s.newClient(t)
+
+	assert.True(t, client.IsAlive())
+	if checker, ok := configuration.As[configuration.HealthChecker](client); ok {
+		status := checker.HealthCheck()
+		assert.True(t, status.Healthy, status.Error)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), watchTimeout)
+	defer cancel()
+	waiter, ok := configuration.As[configuration.ReadinessWaiter](client)
+	if ok {
+		assert.NoError(t, waiter.WaitUntilReady(ctx, types.ReadinessPolicy{}))
+	}
+
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+	if ok {
+		assert.NoError(t, waiter.WaitUntilReady(ctx, types.ReadinessPolicy{WaitForConfiguration: true}))
+	}
+
+	_, err := client.GetConfiguration(&Config{})
+	require.NoError(t, err)
+	if reporter, ok := configuration.As[configuration.CacheReporter](client); ok {
+		assert.False(t, reporter.CacheStatus().Stale)
+	}
+}
+
+func (s *clientSuite) testWatchForChanges(t *testing.T) {
+	client, _, getMsgClientCb := s.newClient(t)
+	if getMsgClientCb == nil {
+		t.Skip("the provider doesn't publish the configuration changes")
+	}
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", getMsgClientCb)
+	defer client.StopWatching()
+
+	// the first update is always nil
+	update := receiveUpdate(t, updateChannel, errorChannel)
+	assert.Nil(t, update)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	update = receiveUpdate(t, updateChannel, errorChannel)
+	require.IsType(t, &WritableInfo{}, update)
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Interval: 30}, *update.(*WritableInfo))
+}
+
+func (s *clientSuite) testWatchForChangesByFullPath(t *testing.T) {
+	common, commonPath, _ := s.newClient(t)
+	require.NoError(t, common.PutConfiguration(newConfig(), true))
+	client, _, getMsgClientCb := s.newClient(t)
+	if getMsgClientCb == nil {
+		t.Skip("the provider doesn't publish the configuration changes")
+	}
+	watcher := feature[configuration.FullPathWatcher](t, client)
+
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	watcher.WatchForChangesByFullPath(updateChannel, errorChannel, &WritableInfo{}, path.Join(commonPath, "Writable"), getMsgClientCb)
+	defer client.StopWatching()
+
+	// the first update is always nil
+	update := receiveUpdate(t, updateChannel, errorChannel)
+	assert.Nil(t, update)
+
+	// the changes made to the configuration of another service are received
+	require.NoError(t, common.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	update = receiveUpdate(t, updateChannel, errorChannel)
+	require.IsType(t, &WritableInfo{}, update)
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Interval: 30}, *update.(*WritableInfo))
+}
+
+func (s *clientSuite) testOnChange(t *testing.T) {
+	client, _, getMsgClientCb := s.newClient(t)
+	if getMsgClientCb == nil {
+		t.Skip("the provider doesn't publish the configuration changes")
+	}
+	feature[configuration.HandlerWatcher](t, client)
+	require.NoError(t, client.PutConfiguration(newConfig(), true))
+
+	type change struct {
+		old, new WritableInfo
+	}
+	changes := make(chan change, 10)
+	// the errors are passed from the goroutine of the watch, so they're checked by the test goroutine
+	errorChannel := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := configuration.OnChange(ctx, client, "Writable", func(old, new WritableInfo) error {
+		changes <- change{old: old, new: new}
+		return nil
+	}, func(err error) {
+		select {
+		case errorChannel <- err:
+		default:
+		}
+	}, getMsgClientCb)
+	require.NoError(t, err)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	select {
+	case received := <-changes:
+		assert.Equal(t, change{old: newConfig().Writable, new: WritableInfo{LogLevel: "DEBUG", Interval: 30}}, received)
+	case err = <-errorChannel:
+		require.NoError(t, err, "the watch failed")
+	case <-time.After(watchTimeout):
+		require.Fail(t, "no change received from the watch")
+	}
+
+	// no error was passed before the watch is stopped
+	select {
+	case err = <-errorChannel:
+		assert.NoError(t, err, "the watch failed")
+	default:
+	}
+	cancel()
+}
+
+// feature returns the optional feature T of client, skipping the test if it isn't implemented
+func feature[T any](t *testing.T, client configuration.Client) T {
+	implemented, ok := configuration.As[T](client)
+	if !ok {
+		t.Skipf("the provider doesn't implement %T", (*T)(nil))
+	}
+	return implemented
+}
+
+func receiveUpdate(t *testing.T, updateChannel chan interface{}, errorChannel chan error) interface{} {
+	select {
+	case update := <-updateChannel:
+		return update
+	case err := <-errorChannel:
+		require.NoError(t, err, "the watch failed")
+	case <-time.After(watchTimeout):
+		require.Fail(t, "no update received from the watch")
+	}
+	return nil
+}
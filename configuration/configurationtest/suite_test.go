@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configurationtest_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration/configurationtest"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/keepertest"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+type nullAuthenticationInjector struct{}
+
+func (nullAuthenticationInjector) AddAuthenticationData(_ *http.Request) error {
+	return nil
+}
+
+func (nullAuthenticationInjector) RoundTripper() http.RoundTripper {
+	return nil
+}
+
+func TestKeeperConformance(t *testing.T) {
+	bus := keepertest.NewMessageBus()
+	keeper := keepertest.NewServer()
+	keeper.SetMessageClient(bus.NewClient())
+	server := keeper.Start()
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	configurationtest.RunClientSuite(t, func(t *testing.T, basePath string) (configuration.Client, func() messaging.MessageClient) {
+		client, err := configuration.NewConfigurationClient(types.ServiceConfig{
+			Host:         serverUrl.Hostname(),
+			Port:         port,
+			Type:         configuration.KeeperProvider,
+			BasePath:     basePath,
+			AuthInjector: nullAuthenticationInjector{},
+		})
+		require.NoError(t, err)
+		return client, bus.NewClient
+	})
+}
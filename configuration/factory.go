@@ -20,21 +20,24 @@ package configuration
 import (
 	"fmt"
 
-	"github.com/edgexfoundry/go-mod-configuration/v4/internal/pkg/keeper"
 	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
 )
 
+// NewConfigurationClient creates the Client of the provider registered for config.Type, see RegisterProvider
 func NewConfigurationClient(config types.ServiceConfig) (Client, error) {
 
 	if config.Host == "" || config.Port == 0 {
 		return nil, fmt.Errorf("unable to create Configuration Client: Configuration service host and/or port or serviceKey not set")
 	}
 
-	switch config.Type {
-	case "keeper":
-		client := keeper.NewKeeperClient(config)
-		return client, nil
-	default:
+	factory, exists := lookupProvider(config.Type)
+	if !exists {
 		return nil, fmt.Errorf("unknown configuration client type '%s' requested", config.Type)
 	}
+
+	client, err := factory(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Configuration Client: %v", err)
+	}
+	return newInstrumentedClient(client, config.Metrics), nil
 }
@@ -0,0 +1,152 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// ErrNotSupported is returned by the functions requiring an optional feature the Client doesn't implement
+var ErrNotSupported = errors.New("not supported by the configuration provider")
+
+// FullPathReader is implemented by the Clients reading the configuration stored under the path of another service,
+// i.e. the common configuration
+type FullPathReader interface {
+	// GetConfigurationByFullPath gets the configuration under the full path from the Configuration service into the
+	// target configuration struct. The `${Other/Key/Path}` references are relative to the base path of the service the
+	// full path belongs to.
+	// The configuration isn't loaded from the local cache when the Configuration service is unreachable.
+	GetConfigurationByFullPath(configStruct interface{}, fullPath string) (interface{}, error)
+
+	// GetConfigurationKeysByFullPath returns all keys under the full path
+	GetConfigurationKeysByFullPath(fullPath string) ([]string, error)
+}
+
+// FullPathWatcher is implemented by the Clients watching the configuration stored under the path of another service
+type FullPathWatcher interface {
+	// WatchForChangesByFullPath sets up a watch for the key given the full path like WatchForChanges
+	WatchForChangesByFullPath(updateChannel chan<- interface{}, errorChannel chan<- error, configuration interface{}, fullPath string, getMsgClientCb func() messaging.MessageClient)
+}
+
+// MultipleWatcher is implemented by the Clients watching several keys over a single message bus subscription
+type MultipleWatcher interface {
+	// WatchForMultipleChanges watches the keys of targets, whose values are decoded into their target struct. Sends a
+	// types.WatchUpdate on updateChannel once the configuration under one of the keys is updated, without the nil update
+	// sent first by WatchForChanges.
+	// Each call is stopped by one call of StopWatching.
+	WatchForMultipleChanges(updateChannel chan<- interface{}, errorChannel chan<- error, targets []types.WatchTarget, getMsgClientCb func() messaging.MessageClient)
+}
+
+// HandlerWatcher is implemented by the Clients watching the configuration with a handler validating each change, see
+// OnChange
+type HandlerWatcher interface {
+	// WatchForChangesWithHandler decodes the current configuration under waitKey into configuration, and watches waitKey
+	// like WatchForChanges. Rather than sending the updates, handler is called serially with configuration and the
+	// updated configuration, decoded into a new value of the same type, before configuration is updated. An error
+	// returned by handler rejects the change, which is reverted in the Configuration service.
+	// The errors of the watch, including the rejected changes as a *types.RejectedChangeError, are passed to
+	// errorHandler if set.
+	// Returns the function stopping this watch only, which StopWatching doesn't stop, or an error if the current
+	// configuration can't be got or the watch can't start.
+	WatchForChangesWithHandler(configuration interface{}, waitKey string, handler func(old, new interface{}) error, errorHandler func(error), getMsgClientCb func() messaging.MessageClient) (func(), error)
+}
+
+// HealthChecker is implemented by the Clients reporting the health of the Configuration service
+type HealthChecker interface {
+	// HealthCheck reports the reachability, latency, version and authentication status of the Configuration service,
+	// together with the client's last successful read and write, its active watches and the last watch error
+	HealthCheck() types.HealthStatus
+}
+
+// ReadinessWaiter is implemented by the Clients waiting for the Configuration service to be ready
+type ReadinessWaiter interface {
+	// WaitUntilReady blocks until the Configuration service is up and running and, if required by the policy,
+	// contains the service's configuration. Returns a *types.ReadinessError describing why the Configuration
+	// service isn't ready if ctx expires first.
+	WaitUntilReady(ctx context.Context, policy types.ReadinessPolicy) error
+}
+
+// EntryReader is implemented by the Clients reading the configuration values along with their metadata
+type EntryReader interface {
+	// GetConfigurationEntry gets a specific configuration value from the Configuration service along with its revision
+	// metadata, to be passed to CheckAndPutConfigurationValue
+	GetConfigurationEntry(name string) (types.ConfigurationEntry, error)
+
+	// GetConfigurationEntries gets the configuration values under name, and the value of name if any, from the
+	// Configuration service along with their metadata, sorted by key
+	GetConfigurationEntries(name string) ([]types.ConfigurationEntry, error)
+
+	// GetConfigurationEntriesModifiedSince gets the configuration values under name, and the value of name if any, which
+	// were modified at or after since, along with their metadata, sorted by key. The values whose modification time
+	// isn't known are always returned.
+	GetConfigurationEntriesModifiedSince(name string, since time.Time) ([]types.ConfigurationEntry, error)
+}
+
+// StoredEntryReader is implemented by the Clients reading the configuration values as stored, without decrypting them
+type StoredEntryReader interface {
+	// GetStoredConfigurationEntries gets the configuration values under name, and the value of name if any, from the
+	// Configuration service as stored, i.e. with the sensitive values still encrypted, along with their metadata,
+	// sorted by key
+	GetStoredConfigurationEntries(name string) ([]types.ConfigurationEntry, error)
+}
+
+// ConditionalWriter is implemented by the Clients putting a configuration value only if the current one meets a
+// precondition
+type ConditionalWriter interface {
+	// CheckAndPutConfigurationValue puts a specific configuration value into the Configuration service only if the
+	// current value meets precondition, and returns an error matching types.ErrConflict otherwise.
+	// The check isn't atomic with the put unless the provider documents otherwise: a value written concurrently between
+	// them may be overwritten.
+	CheckAndPutConfigurationValue(name string, value []byte, precondition types.Precondition) error
+}
+
+// ValueDeleter is implemented by the Clients deleting configuration values
+type ValueDeleter interface {
+	// DeleteConfigurationValue deletes a specific configuration value from the Configuration service,
+	// along with all the values under it if deep is true
+	DeleteConfigurationValue(name string, deep bool) error
+}
+
+// KeyRotator is implemented by the Clients encrypting the sensitive configuration values
+type KeyRotator interface {
+	// RotateEncryptionKey re-encrypts the sensitive values stored in the Configuration service with the current key
+	// of the configured KeyProvider, including the sensitive values which are still stored as plaintext
+	RotateEncryptionKey() error
+}
+
+// CacheReporter is implemented by the Clients serving the configuration from a local cache
+type CacheReporter interface {
+	// CacheStatus returns whether the configuration returned by the last GetConfiguration was served from the local
+	// cache because the Configuration service was unreachable, and when the cached configuration was retrieved
+	CacheStatus() types.CacheStatus
+}
+
+// BasePathReporter is implemented by the Clients reporting the base path their keys are relative to
+type BasePathReporter interface {
+	// BasePath returns the base path of the configuration the Client was created for
+	BasePath() string
+}
+
+// As returns the optional feature T, one of the interfaces above or io.Closer, implemented by client.
+// It sees through the Client returned by NewConfigurationClient, whose metrics also cover the operations of the feature.
+// Returns false if the feature isn't implemented.
+func As[T any](client Client) (T, bool) {
+	if decorator, ok := client.(*instrumentedClient); ok {
+		feature, ok := decorator.Client.(T)
+		if !ok {
+			return feature, false
+		}
+		return instrumentedFeature(decorator, feature), true
+	}
+	feature, ok := client.(T)
+	return feature, ok
+}
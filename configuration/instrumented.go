@@ -0,0 +1,260 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"context"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// instrumentedClient decorates a Client to report the count, latency and errors of its operations to a MetricsRecorder.
+// The methods which aren't operations on the Configuration service are passed through to the embedded Client.
+type instrumentedClient struct {
+	Client
+	metrics types.MetricsRecorder
+}
+
+func newInstrumentedClient(client Client, metrics types.MetricsRecorder) Client {
+	if metrics == nil {
+		return client
+	}
+	return &instrumentedClient{
+		Client:  client,
+		metrics: metrics,
+	}
+}
+
+// observe records the duration and the outcome of the operation started at start
+func (c *instrumentedClient) observe(operation string, start time.Time, err error) {
+	c.metrics.ObserveOperation(operation, time.Since(start), types.ErrorKind(err))
+}
+
+func (c *instrumentedClient) HasConfiguration() (bool, error) {
+	start := time.Now()
+	exists, err := c.Client.HasConfiguration()
+	c.observe("HasConfiguration", start, err)
+	return exists, err
+}
+
+func (c *instrumentedClient) HasSubConfiguration(name string) (bool, error) {
+	start := time.Now()
+	exists, err := c.Client.HasSubConfiguration(name)
+	c.observe("HasSubConfiguration", start, err)
+	return exists, err
+}
+
+func (c *instrumentedClient) PutConfigurationMap(configuration map[string]any, overwrite bool) error {
+	start := time.Now()
+	err := c.Client.PutConfigurationMap(configuration, overwrite)
+	c.observe("PutConfigurationMap", start, err)
+	return err
+}
+
+func (c *instrumentedClient) PutConfiguration(configStruct interface{}, overwrite bool) error {
+	start := time.Now()
+	err := c.Client.PutConfiguration(configStruct, overwrite)
+	c.observe("PutConfiguration", start, err)
+	return err
+}
+
+func (c *instrumentedClient) GetConfiguration(configStruct interface{}) (interface{}, error) {
+	start := time.Now()
+	result, err := c.Client.GetConfiguration(configStruct)
+	c.observe("GetConfiguration", start, err)
+	return result, err
+}
+
+func (c *instrumentedClient) IsAlive() bool {
+	start := time.Now()
+	alive := c.Client.IsAlive()
+	var err error
+	if !alive {
+		err = types.ErrUnavailable
+	}
+	c.observe("IsAlive", start, err)
+	return alive
+}
+
+func (c *instrumentedClient) ConfigurationValueExists(name string) (bool, error) {
+	start := time.Now()
+	exists, err := c.Client.ConfigurationValueExists(name)
+	c.observe("ConfigurationValueExists", start, err)
+	return exists, err
+}
+
+func (c *instrumentedClient) GetConfigurationValue(name string) ([]byte, error) {
+	start := time.Now()
+	value, err := c.Client.GetConfigurationValue(name)
+	c.observe("GetConfigurationValue", start, err)
+	return value, err
+}
+
+func (c *instrumentedClient) GetConfigurationValueByFullPath(fullPath string) ([]byte, error) {
+	start := time.Now()
+	value, err := c.Client.GetConfigurationValueByFullPath(fullPath)
+	c.observe("GetConfigurationValueByFullPath", start, err)
+	return value, err
+}
+
+func (c *instrumentedClient) PutConfigurationValue(name string, value []byte) error {
+	start := time.Now()
+	err := c.Client.PutConfigurationValue(name, value)
+	c.observe("PutConfigurationValue", start, err)
+	return err
+}
+
+func (c *instrumentedClient) GetConfigurationKeys(name string) ([]string, error) {
+	start := time.Now()
+	keys, err := c.Client.GetConfigurationKeys(name)
+	c.observe("GetConfigurationKeys", start, err)
+	return keys, err
+}
+
+// instrumentedFeature returns the feature of the Client decorated by c, with its operations instrumented like the ones
+// of c. The features which aren't operations on the Configuration service are returned as is.
+func instrumentedFeature[T any](c *instrumentedClient, feature T) T {
+	var instrumented any
+	switch any((*T)(nil)).(type) {
+	case *FullPathReader:
+		instrumented = instrumentedFullPathReader{instrumentedClient: c, feature: any(feature).(FullPathReader)}
+	case *HealthChecker:
+		instrumented = instrumentedHealthChecker{instrumentedClient: c, feature: any(feature).(HealthChecker)}
+	case *ReadinessWaiter:
+		instrumented = instrumentedReadinessWaiter{instrumentedClient: c, feature: any(feature).(ReadinessWaiter)}
+	case *EntryReader:
+		instrumented = instrumentedEntryReader{instrumentedClient: c, feature: any(feature).(EntryReader)}
+	case *StoredEntryReader:
+		instrumented = instrumentedStoredEntryReader{instrumentedClient: c, feature: any(feature).(StoredEntryReader)}
+	case *ConditionalWriter:
+		instrumented = instrumentedConditionalWriter{instrumentedClient: c, feature: any(feature).(ConditionalWriter)}
+	case *ValueDeleter:
+		instrumented = instrumentedValueDeleter{instrumentedClient: c, feature: any(feature).(ValueDeleter)}
+	case *KeyRotator:
+		instrumented = instrumentedKeyRotator{instrumentedClient: c, feature: any(feature).(KeyRotator)}
+	default:
+		return feature
+	}
+	return instrumented.(T)
+}
+
+type instrumentedFullPathReader struct {
+	*instrumentedClient
+	feature FullPathReader
+}
+
+func (c instrumentedFullPathReader) GetConfigurationByFullPath(configStruct interface{}, fullPath string) (interface{}, error) {
+	start := time.Now()
+	result, err := c.feature.GetConfigurationByFullPath(configStruct, fullPath)
+	c.observe("GetConfigurationByFullPath", start, err)
+	return result, err
+}
+
+func (c instrumentedFullPathReader) GetConfigurationKeysByFullPath(fullPath string) ([]string, error) {
+	start := time.Now()
+	keys, err := c.feature.GetConfigurationKeysByFullPath(fullPath)
+	c.observe("GetConfigurationKeysByFullPath", start, err)
+	return keys, err
+}
+
+type instrumentedHealthChecker struct {
+	*instrumentedClient
+	feature HealthChecker
+}
+
+func (c instrumentedHealthChecker) HealthCheck() types.HealthStatus {
+	start := time.Now()
+	status := c.feature.HealthCheck()
+	c.observe("HealthCheck", start, status.Err())
+	return status
+}
+
+type instrumentedReadinessWaiter struct {
+	*instrumentedClient
+	feature ReadinessWaiter
+}
+
+func (c instrumentedReadinessWaiter) WaitUntilReady(ctx context.Context, policy types.ReadinessPolicy) error {
+	start := time.Now()
+	err := c.feature.WaitUntilReady(ctx, policy)
+	c.observe("WaitUntilReady", start, err)
+	return err
+}
+
+type instrumentedEntryReader struct {
+	*instrumentedClient
+	feature EntryReader
+}
+
+func (c instrumentedEntryReader) GetConfigurationEntry(name string) (types.ConfigurationEntry, error) {
+	start := time.Now()
+	entry, err := c.feature.GetConfigurationEntry(name)
+	c.observe("GetConfigurationEntry", start, err)
+	return entry, err
+}
+
+func (c instrumentedEntryReader) GetConfigurationEntries(name string) ([]types.ConfigurationEntry, error) {
+	start := time.Now()
+	entries, err := c.feature.GetConfigurationEntries(name)
+	c.observe("GetConfigurationEntries", start, err)
+	return entries, err
+}
+
+func (c instrumentedEntryReader) GetConfigurationEntriesModifiedSince(name string, since time.Time) ([]types.ConfigurationEntry, error) {
+	start := time.Now()
+	entries, err := c.feature.GetConfigurationEntriesModifiedSince(name, since)
+	c.observe("GetConfigurationEntriesModifiedSince", start, err)
+	return entries, err
+}
+
+type instrumentedStoredEntryReader struct {
+	*instrumentedClient
+	feature StoredEntryReader
+}
+
+func (c instrumentedStoredEntryReader) GetStoredConfigurationEntries(name string) ([]types.ConfigurationEntry, error) {
+	start := time.Now()
+	entries, err := c.feature.GetStoredConfigurationEntries(name)
+	c.observe("GetStoredConfigurationEntries", start, err)
+	return entries, err
+}
+
+type instrumentedConditionalWriter struct {
+	*instrumentedClient
+	feature ConditionalWriter
+}
+
+func (c instrumentedConditionalWriter) CheckAndPutConfigurationValue(name string, value []byte, precondition types.Precondition) error {
+	start := time.Now()
+	err := c.feature.CheckAndPutConfigurationValue(name, value, precondition)
+	c.observe("CheckAndPutConfigurationValue", start, err)
+	return err
+}
+
+type instrumentedValueDeleter struct {
+	*instrumentedClient
+	feature ValueDeleter
+}
+
+func (c instrumentedValueDeleter) DeleteConfigurationValue(name string, deep bool) error {
+	start := time.Now()
+	err := c.feature.DeleteConfigurationValue(name, deep)
+	c.observe("DeleteConfigurationValue", start, err)
+	return err
+}
+
+type instrumentedKeyRotator struct {
+	*instrumentedClient
+	feature KeyRotator
+}
+
+func (c instrumentedKeyRotator) RotateEncryptionKey() error {
+	start := time.Now()
+	err := c.feature.RotateEncryptionKey()
+	c.observe("RotateEncryptionKey", start, err)
+	return err
+}
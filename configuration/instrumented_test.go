@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+type observation struct {
+	operation string
+	errorKind string
+}
+
+type recordingMetrics struct {
+	observations []observation
+}
+
+func (r *recordingMetrics) ObserveOperation(operation string, duration time.Duration, errorKind string) {
+	r.observations = append(r.observations, observation{operation: operation, errorKind: errorKind})
+}
+
+func (r *recordingMetrics) IncWatchMessages(types.WatchOutcome) {}
+
+func TestInstrumentedClient(t *testing.T) {
+	mockClient := &mocks.Client{}
+	mockClient.On("GetConfigurationValue", "Writable/LogLevel").Return([]byte("INFO"), nil)
+	mockClient.On("GetConfigurationValue", "Missing").Return(nil, &types.OperationError{Kind: types.ErrNotFound, Message: "not found"})
+	mockClient.On("PutConfigurationValue", "Writable/LogLevel", []byte("DEBUG")).Return(types.ErrUnauthorized)
+	mockClient.On("IsAlive").Return(false)
+
+	metrics := &recordingMetrics{}
+	client := newInstrumentedClient(mockClient, metrics)
+
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("INFO"), value)
+	_, err = client.GetConfigurationValue("Missing")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	err = client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG"))
+	assert.ErrorIs(t, err, types.ErrUnauthorized)
+	assert.False(t, client.IsAlive())
+
+	expected := []observation{
+		{operation: "GetConfigurationValue"},
+		{operation: "GetConfigurationValue", errorKind: types.ErrorKindNotFound},
+		{operation: "PutConfigurationValue", errorKind: types.ErrorKindUnauthorized},
+		{operation: "IsAlive", errorKind: types.ErrorKindUnavailable},
+	}
+	assert.Equal(t, expected, metrics.observations)
+	mockClient.AssertExpectations(t)
+}
+
+func TestInstrumentedClientDisabled(t *testing.T) {
+	mockClient := &mocks.Client{}
+	assert.Same(t, mockClient, newInstrumentedClient(mockClient, nil))
+}
+
+// featureClient is a Client implementing some of the optional features
+type featureClient struct {
+	*mocks.Client
+	deleted string
+	closed  bool
+}
+
+func (c *featureClient) DeleteConfigurationValue(name string, _ bool) error {
+	c.deleted = name
+	return types.ErrUnavailable
+}
+
+func (c *featureClient) CacheStatus() types.CacheStatus {
+	return types.CacheStatus{Stale: true}
+}
+
+func (c *featureClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestAs(t *testing.T) {
+	inner := &featureClient{Client: &mocks.Client{}}
+	metrics := &recordingMetrics{}
+
+	for _, client := range []Client{inner, newInstrumentedClient(inner, metrics)} {
+		deleter, ok := As[ValueDeleter](client)
+		require.True(t, ok)
+		assert.ErrorIs(t, deleter.DeleteConfigurationValue("Writable", true), types.ErrUnavailable)
+		assert.Equal(t, "Writable", inner.deleted)
+
+		reporter, ok := As[CacheReporter](client)
+		require.True(t, ok)
+		assert.True(t, reporter.CacheStatus().Stale)
+
+		closer, ok := As[io.Closer](client)
+		require.True(t, ok)
+		require.NoError(t, closer.Close())
+		assert.True(t, inner.closed)
+
+		_, ok = As[KeyRotator](client)
+		assert.False(t, ok)
+	}
+
+	// only the operations on the Configuration service are observed, once
+	expected := []observation{{operation: "DeleteConfigurationValue", errorKind: types.ErrorKindUnavailable}}
+	assert.Equal(t, expected, metrics.observations)
+}
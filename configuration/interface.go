@@ -19,6 +19,11 @@ package configuration
 
 import "github.com/edgexfoundry/go-mod-messaging/v4/messaging"
 
+// Client is the interface of the Configuration service clients.
+// The returned errors can be checked with errors.Is against types.ErrNotFound, types.ErrUnauthorized,
+// types.ErrUnavailable, types.ErrDecode and types.ErrConflict.
+// The optional features of a provider are implemented by its Client as the separate interfaces of features.go, which
+// are looked up with As.
 type Client interface {
 	// HasConfiguration checks to see if the Configuration service contains the service's configuration.
 	HasConfiguration() (bool, error)
@@ -36,11 +41,15 @@ type Client interface {
 	// GetConfiguration gets the full configuration from keeper into the target configuration struct.
 	// Passed in struct is only a reference for Configuration service. Empty struct is fine
 	// Returns the configuration in the target struct as interface{}, which caller must cast
+	// The `${Other/Key/Path}` and `${ENV:VAR}` references in the stored values are resolved before decoding.
 	GetConfiguration(configStruct interface{}) (interface{}, error)
 
 	// WatchForChanges sets up a keeper watch for the target key and send back updates on the update channel.
 	// Passed in struct is only a reference for Configuration service, empty struct is ok
 	// Sends the configuration in the target struct as interface{} on updateChannel, which caller must cast
+	// How the updates and the errors are sent while the caller is busy is set by the WatchDeliveryPolicy option.
+	// A configuration implementing types.ConfigurationValidator is validated before it's updated, and the rejected
+	// changes are reported on errorChannel as a *types.RejectedChangeError.
 	WatchForChanges(updateChannel chan<- interface{}, errorChannel chan<- error, configuration interface{}, waitKey string, getMsgClientCb func() messaging.MessageClient)
 
 	// StopWatching causes all WatchForChanges processing to stop and waits until they have stopped.
@@ -0,0 +1,128 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package migration upgrades the configuration a service stored in the Configuration service with a previous release,
+// by applying the ordered migrations registered by the service once. The version of the last migration applied is
+// recorded under the service's base path, so that running the migrations again only applies the new ones.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// VersionKey is the key, relative to the base path, the version of the last migration applied is stored at
+const VersionKey = "SchemaVersion"
+
+// Migration is a set of steps which upgrades the configuration to Version
+type Migration struct {
+	// Version is the schema version of the configuration once migrated, which must be greater than the version of the
+	// migrations registered before
+	Version int
+	// Description describes the migration in the errors
+	Description string
+	// Steps are the changes applied in order. Every step must be idempotent, so that a migration interrupted before its
+	// version is recorded can be applied again.
+	Steps []Step
+}
+
+// Migrator applies the registered migrations to the configuration stored under a base path. Running the same
+// migrations concurrently, i.e. from several instances of a service, isn't supported.
+type Migrator struct {
+	client     configuration.Client
+	basePath   string
+	migrations []Migration
+}
+
+// NewMigrator creates a Migrator of the configuration client was created for.
+// Returns an error matching configuration.ErrNotSupported if client doesn't implement configuration.BasePathReporter,
+// as the keys of the configuration are listed relative to its base path.
+func NewMigrator(client configuration.Client) (*Migrator, error) {
+	reporter, ok := configuration.As[configuration.BasePathReporter](client)
+	if !ok {
+		return nil, fmt.Errorf("unable to get the base path of the configuration to migrate: %w", configuration.ErrNotSupported)
+	}
+	return &Migrator{
+		client:   client,
+		basePath: strings.TrimSuffix(reporter.BasePath(), "/"),
+	}, nil
+}
+
+// Register adds migrations, which must be ordered by increasing version, to the ones applied by Run
+func (m *Migrator) Register(migrations ...Migration) error {
+	last := 0
+	if len(m.migrations) > 0 {
+		last = m.migrations[len(m.migrations)-1].Version
+	}
+	for _, migration := range migrations {
+		if migration.Version <= last {
+			return fmt.Errorf("migration %d (%s) must have a version greater than %d", migration.Version, migration.Description, last)
+		}
+		last = migration.Version
+	}
+	m.migrations = append(m.migrations, migrations...)
+	return nil
+}
+
+// Version returns the version of the last migration applied, 0 if none was
+func (m *Migrator) Version() (int, error) {
+	value, err := m.client.GetConfigurationValue(VersionKey)
+	if err != nil {
+		if errors.Is(err, types.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("unable to get the configuration schema version: %w", err)
+	}
+	version, err := strconv.Atoi(string(value))
+	if err != nil {
+		return 0, fmt.Errorf("the configuration schema version %q is invalid: %v", value, err)
+	}
+	return version, nil
+}
+
+// Run applies the migrations whose version is greater than the recorded version, recording the version of each one
+// once its steps are applied, and returns the resulting version. The migrations following a failed one aren't applied.
+func (m *Migrator) Run() (int, error) {
+	version, err := m.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			continue
+		}
+		for index, step := range migration.Steps {
+			if err = step.apply(m); err != nil {
+				return version, fmt.Errorf("migration %d (%s) failed at step %d, %s: %w", migration.Version, migration.Description, index+1, step.description, err)
+			}
+		}
+		if err = m.client.PutConfigurationValue(VersionKey, []byte(strconv.Itoa(migration.Version))); err != nil {
+			return version, fmt.Errorf("unable to record the configuration schema version %d: %w", migration.Version, err)
+		}
+		version = migration.Version
+	}
+	return version, nil
+}
+
+// relativeKey returns the key relative to the base path of a full key path
+func (m *Migrator) relativeKey(fullKey string) string {
+	return strings.TrimPrefix(fullKey, m.basePath+"/")
+}
+
+// deleteValue deletes key, along with the keys under it if deep is true, if the client implements
+// configuration.ValueDeleter
+func (m *Migrator) deleteValue(key string, deep bool) error {
+	deleter, ok := configuration.As[configuration.ValueDeleter](m.client)
+	if !ok {
+		return fmt.Errorf("unable to delete %s: %w", key, configuration.ErrNotSupported)
+	}
+	return deleter.DeleteConfigurationValue(key, deep)
+}
@@ -0,0 +1,284 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/keepertest"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBasePath = "edgex/v4/core-data"
+
+type nullAuthenticationInjector struct{}
+
+func (nullAuthenticationInjector) AddAuthenticationData(_ *http.Request) error {
+	return nil
+}
+
+func (nullAuthenticationInjector) RoundTripper() http.RoundTripper {
+	return nil
+}
+
+func makeClient(t *testing.T, basePath string) (configuration.Client, *keepertest.Server) {
+	return makeEncryptingClient(t, basePath, nil)
+}
+
+// makeEncryptingClient creates a client encrypting the sensitive values with keyProvider, if not nil
+func makeEncryptingClient(t *testing.T, basePath string, keyProvider types.KeyProvider) (configuration.Client, *keepertest.Server) {
+	keeper := keepertest.NewServer()
+	server := keeper.Start()
+	t.Cleanup(server.Close)
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	client, err := configuration.NewConfigurationClient(types.ServiceConfig{
+		Host:         serverUrl.Hostname(),
+		Port:         port,
+		Type:         configuration.KeeperProvider,
+		BasePath:     basePath,
+		AuthInjector: nullAuthenticationInjector{},
+		KeyProvider:  keyProvider,
+	})
+	require.NoError(t, err)
+	return client, keeper
+}
+
+func storedValues(keeper *keepertest.Server) map[string]any {
+	values := make(map[string]any)
+	for _, key := range keeper.Keys() {
+		kv, _ := keeper.Get(key)
+		values[strings.TrimPrefix(key, testBasePath+"/")] = kv.Value
+	}
+	return values
+}
+
+// millisecondsToDuration changes a timeout in milliseconds, i.e. "5000", to a duration, i.e. "5s"
+func millisecondsToDuration(value string) (string, error) {
+	if _, err := time.ParseDuration(value); err == nil {
+		return value, nil
+	}
+	milliseconds, err := strconv.Atoi(value)
+	if err != nil {
+		return "", err
+	}
+	return (time.Duration(milliseconds) * time.Millisecond).String(), nil
+}
+
+func TestRun(t *testing.T) {
+	client, keeper := makeClient(t, testBasePath)
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO"},
+		"Clients":  map[string]any{"core-metadata": map[string]any{"Host": "localhost", "Port": 59881}},
+		"Service":  map[string]any{"Timeout": 5000},
+		"Obsolete": map[string]any{"Enabled": true},
+	}, true))
+
+	customRuns := 0
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Register(
+		Migration{
+			Version:     1,
+			Description: "rename the log level",
+			Steps:       []Step{RenameKey("Writable/LogLevel", "Writable/Level")},
+		},
+		Migration{
+			Version:     3,
+			Description: "v4 layout",
+			Steps: []Step{
+				MoveSubtree("Clients", "ServiceClients"),
+				ChangeValue("Service/Timeout", millisecondsToDuration),
+				DeleteKey("Obsolete"),
+				Custom("count the runs", func(configuration.Client) error {
+					customRuns++
+					return nil
+				}),
+			},
+		},
+	))
+
+	version, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	version, err = migrator.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	expected := map[string]any{
+		"Writable/Level":                    "INFO",
+		"ServiceClients/core-metadata/Host": "localhost",
+		"ServiceClients/core-metadata/Port": "59881",
+		"Service/Timeout":                   "5s",
+		VersionKey:                          "3",
+	}
+	assert.Equal(t, expected, storedValues(keeper))
+	assert.Equal(t, 1, customRuns)
+
+	// the migrations already applied are skipped
+	version, err = migrator.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.Equal(t, expected, storedValues(keeper))
+	assert.Equal(t, 1, customRuns)
+
+	// only the new migrations are applied
+	require.NoError(t, migrator.Register(Migration{Version: 4, Steps: []Step{DeleteKey("Service")}}))
+	version, err = migrator.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 4, version)
+	assert.Equal(t, 1, customRuns)
+	assert.NotContains(t, storedValues(keeper), "Service/Timeout")
+}
+
+func TestRunInterrupted(t *testing.T) {
+	client, keeper := makeClient(t, testBasePath)
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO"},
+		"Service":  map[string]any{"Timeout": 5000},
+	}, true))
+
+	failure := errors.New("interrupted")
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Register(
+		Migration{Version: 1, Steps: []Step{ChangeValue("Service/Timeout", millisecondsToDuration)}},
+		Migration{
+			Version:     2,
+			Description: "rename the log level",
+			Steps: []Step{
+				RenameKey("Writable/LogLevel", "Writable/Level"),
+				Custom("fail", func(configuration.Client) error { return failure }),
+			},
+		},
+	))
+
+	version, err := migrator.Run()
+	require.ErrorIs(t, err, failure)
+	assert.Equal(t, "migration 2 (rename the log level) failed at step 2, fail: interrupted", err.Error())
+	assert.Equal(t, 1, version)
+	version, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	// the steps applied before the failure are skipped once applied again
+	failure = nil
+	version, err = migrator.Run()
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+	assert.Equal(t, map[string]any{
+		"Writable/Level":  "INFO",
+		"Service/Timeout": "5s",
+		VersionKey:        "2",
+	}, storedValues(keeper))
+}
+
+func TestSteps(t *testing.T) {
+	client, keeper := makeClient(t, testBasePath+"/")
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable":  map[string]any{"LogLevel": "INFO", "InsecureSecrets": map[string]any{"DB": map[string]any{"Path": "redisdb"}}},
+		"Writablex": "kept",
+	}, true))
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+
+	// a subtree can't be renamed as a value
+	err = RenameKey("Writable", "Settings").apply(migrator)
+	assert.ErrorContains(t, err, "isn't a value")
+
+	// the keys sharing the prefix of the moved subtree aren't moved
+	require.NoError(t, MoveSubtree("Writable", "Settings").apply(migrator))
+	assert.Equal(t, map[string]any{
+		"Settings/LogLevel":                "INFO",
+		"Settings/InsecureSecrets/DB/Path": "redisdb",
+		"Writablex":                        "kept",
+	}, storedValues(keeper))
+
+	// the missing keys are skipped
+	require.NoError(t, RenameKey("Missing", "Other").apply(migrator))
+	require.NoError(t, MoveSubtree("Missing", "Other").apply(migrator))
+	require.NoError(t, ChangeValue("Missing", func(string) (string, error) { return "", errors.New("not called") }).apply(migrator))
+	require.NoError(t, DeleteKey("Missing").apply(migrator))
+
+	err = ChangeValue("Settings/LogLevel", func(string) (string, error) { return "", errors.New("invalid") }).apply(migrator)
+	assert.ErrorContains(t, err, "invalid")
+}
+
+func TestStepsEncrypted(t *testing.T) {
+	provider, err := types.NewStaticKeyProvider("key1", map[string][]byte{"key1": []byte("0123456789abcdef")})
+	require.NoError(t, err)
+	client, keeper := makeEncryptingClient(t, testBasePath, provider)
+
+	// the password is sensitive because of the tag of its field, not because of its key
+	type database struct {
+		Host     string
+		Password string `config:"sensitive"`
+	}
+	type config struct {
+		Database database
+		Token    string `config:"sensitive"`
+	}
+	require.NoError(t, client.PutConfiguration(config{Database: database{Host: "localhost", Password: "secret"}, Token: "token"}, true))
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+
+	// the moved values stay encrypted
+	require.NoError(t, MoveSubtree("Database", "Databases/Primary").apply(migrator))
+	require.NoError(t, RenameKey("Token", "AccessToken").apply(migrator))
+	stored := storedValues(keeper)
+	assert.Len(t, stored, 3)
+	assert.Equal(t, "localhost", stored["Databases/Primary/Host"])
+	assert.True(t, strings.HasPrefix(stored["Databases/Primary/Password"].(string), "enc:v1:"), "the password is stored as plaintext")
+	assert.True(t, strings.HasPrefix(stored["AccessToken"].(string), "enc:v1:"), "the token is stored as plaintext")
+
+	value, err := client.GetConfigurationValue("Databases/Primary/Password")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(value))
+	value, err = client.GetConfigurationValue("AccessToken")
+	require.NoError(t, err)
+	assert.Equal(t, "token", string(value))
+}
+
+func TestRegister(t *testing.T) {
+	client, _ := makeClient(t, testBasePath)
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+
+	assert.Error(t, migrator.Register(Migration{Version: 0}))
+	assert.Error(t, migrator.Register(Migration{Version: 2}, Migration{Version: 1}))
+	require.NoError(t, migrator.Register(Migration{Version: 2}))
+	assert.Error(t, migrator.Register(Migration{Version: 2}))
+	require.NoError(t, migrator.Register(Migration{Version: 5}))
+}
+
+func TestVersionInvalid(t *testing.T) {
+	client, _ := makeClient(t, testBasePath)
+	require.NoError(t, client.PutConfigurationValue(VersionKey, []byte("v1")))
+
+	migrator, err := NewMigrator(client)
+	require.NoError(t, err)
+	_, err = migrator.Run()
+	assert.ErrorContains(t, err, `the configuration schema version "v1" is invalid`)
+}
+
+func TestNewMigratorNotSupported(t *testing.T) {
+	_, err := NewMigrator(&mocks.Client{})
+	assert.ErrorIs(t, err, configuration.ErrNotSupported)
+}
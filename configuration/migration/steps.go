@@ -0,0 +1,129 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// Step is an idempotent change of the configuration, whose keys are relative to the base path
+type Step struct {
+	description string
+	apply       func(m *Migrator) error
+}
+
+// RenameKey moves the value of from to the key to, overwriting its value if any.
+// The value is moved as stored, so that it stays encrypted if it was.
+// The step is skipped if from doesn't exist, i.e. once it was renamed.
+func RenameKey(from string, to string) Step {
+	return Step{
+		description: fmt.Sprintf("rename %s to %s", from, to),
+		apply: func(m *Migrator) error {
+			entries, err := m.subtreeEntries(from)
+			if err != nil || len(entries) == 0 {
+				return err
+			}
+			index := slices.IndexFunc(entries, func(entry types.ConfigurationEntry) bool { return m.relativeKey(entry.Key) == from })
+			if index < 0 {
+				return fmt.Errorf("%s isn't a value but the parent of other keys", from)
+			}
+			if err = m.client.PutConfigurationValue(to, entries[index].Value); err != nil {
+				return err
+			}
+			return m.deleteValue(from, false)
+		},
+	}
+}
+
+// MoveSubtree moves the values under from, and the value of from if any, under the key to.
+// The values are moved as stored, so that they stay encrypted if they were.
+// The step is skipped if from doesn't exist, i.e. once it was moved.
+func MoveSubtree(from string, to string) Step {
+	return Step{
+		description: fmt.Sprintf("move %s to %s", from, to),
+		apply: func(m *Migrator) error {
+			entries, err := m.subtreeEntries(from)
+			if err != nil || len(entries) == 0 {
+				return err
+			}
+			for _, entry := range entries {
+				key := path.Join(to, strings.TrimPrefix(m.relativeKey(entry.Key), from))
+				if err = m.client.PutConfigurationValue(key, entry.Value); err != nil {
+					return err
+				}
+			}
+			return m.deleteValue(from, true)
+		},
+	}
+}
+
+// ChangeValue replaces the value of key with the value returned by change, i.e. to change its format.
+// The step is skipped if key doesn't exist. As the step may be applied again, change must return the value unchanged
+// if it's already in the new format.
+func ChangeValue(key string, change func(value string) (string, error)) Step {
+	return Step{
+		description: fmt.Sprintf("change the value of %s", key),
+		apply: func(m *Migrator) error {
+			value, err := m.client.GetConfigurationValue(key)
+			if err != nil {
+				if errors.Is(err, types.ErrNotFound) {
+					return nil
+				}
+				return err
+			}
+			changed, err := change(string(value))
+			if err != nil || changed == string(value) {
+				return err
+			}
+			return m.client.PutConfigurationValue(key, []byte(changed))
+		},
+	}
+}
+
+// DeleteKey deletes key along with the keys under it. The step is skipped if key doesn't exist.
+func DeleteKey(key string) Step {
+	return Step{
+		description: fmt.Sprintf("delete %s", key),
+		apply: func(m *Migrator) error {
+			err := m.deleteValue(key, true)
+			if errors.Is(err, types.ErrNotFound) {
+				return nil
+			}
+			return err
+		},
+	}
+}
+
+// Custom is a step applying any other change to the configuration with client, which must be idempotent
+func Custom(description string, apply func(client configuration.Client) error) Step {
+	return Step{
+		description: description,
+		apply: func(m *Migrator) error {
+			return apply(m.client)
+		},
+	}
+}
+
+// subtreeEntries returns the entries of key and of the keys under it as stored, i.e. with the values encrypted by the
+// client still encrypted, if the client implements configuration.StoredEntryReader
+func (m *Migrator) subtreeEntries(key string) ([]types.ConfigurationEntry, error) {
+	reader, ok := configuration.As[configuration.StoredEntryReader](m.client)
+	if !ok {
+		return nil, fmt.Errorf("unable to read %s as stored: %w", key, configuration.ErrNotSupported)
+	}
+	entries, err := reader.GetStoredConfigurationEntries(key)
+	if errors.Is(err, types.ErrNotFound) {
+		return nil, nil
+	}
+	return entries, err
+}
@@ -0,0 +1,50 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+)
+
+// ChangeHandler is called by OnChange with the previous and the updated configuration under the watched key.
+// Returning an error rejects the updated configuration, which is reverted in the Configuration service, so that the
+// previous one is passed again as old to the next call.
+type ChangeHandler[T any] func(old, new T) error
+
+// OnChange watches key with the HandlerWatcher of client, and calls handler with the previous and the updated
+// configuration each time the configuration under key is updated, decoded into a T.
+// The calls are made serially from the goroutine of the watch, before the update is applied, and an update equal to
+// the configuration last accepted is ignored. The errors of the watch, including the changes rejected by handler as a
+// *types.RejectedChangeError, are passed to errorHandler, which may be nil to ignore them.
+// The watch is stopped once ctx is done, it isn't stopped by StopWatching.
+// Returns an error if the current configuration can't be got or the watch can't start, in which case nothing is
+// watched, and ErrNotSupported if client doesn't implement HandlerWatcher.
+func OnChange[T any](ctx context.Context, client Client, key string, handler ChangeHandler[T], errorHandler func(error), getMsgClientCb func() messaging.MessageClient) error {
+	watcher, ok := As[HandlerWatcher](client)
+	if !ok {
+		return fmt.Errorf("unable to watch the changes of %s: %w", key, ErrNotSupported)
+	}
+	stop, err := watcher.WatchForChangesWithHandler(new(T), key, func(old, new interface{}) error {
+		accepted, updated := *old.(*T), *new.(*T)
+		if reflect.DeepEqual(accepted, updated) {
+			return nil
+		}
+		return handler(accepted, updated)
+	}, errorHandler, getMsgClientCb)
+	if err != nil {
+		return fmt.Errorf("unable to watch the changes of %s: %w", key, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return nil
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+type writableInfo struct {
+	LogLevel string
+}
+
+type change struct {
+	old, new string
+}
+
+// handlerWatcherClient is a mocked Client implementing HandlerWatcher, which records the handlers of the watch
+type handlerWatcherClient struct {
+	*mocks.Client
+	err          error
+	handler      func(old, new interface{}) error
+	errorHandler func(error)
+	stopped      chan struct{}
+}
+
+func (c *handlerWatcherClient) WatchForChangesWithHandler(_ interface{}, _ string, handler func(old, new interface{}) error, errorHandler func(error), _ func() messaging.MessageClient) (func(), error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.handler = handler
+	c.errorHandler = errorHandler
+	return func() { close(c.stopped) }, nil
+}
+
+func TestOnChange(t *testing.T) {
+	client := &handlerWatcherClient{Client: &mocks.Client{}, stopped: make(chan struct{})}
+	changes := make(chan change, 10)
+	errorsReceived := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := OnChange(ctx, client, "Writable", func(old, new writableInfo) error {
+		changes <- change{old: old.LogLevel, new: new.LogLevel}
+		if new.LogLevel == "ERROR" {
+			return errors.New("log level not supported")
+		}
+		return nil
+	}, func(err error) { errorsReceived <- err }, func() messaging.MessageClient { return nil })
+	require.NoError(t, err)
+
+	// the handler is called with the configuration decoded by the watch
+	require.NoError(t, client.handler(&writableInfo{LogLevel: "INFO"}, &writableInfo{LogLevel: "DEBUG"}))
+	assert.Equal(t, change{old: "INFO", new: "DEBUG"}, <-changes)
+	// the error rejecting the change is returned to the watch, which reverts it
+	assert.EqualError(t, client.handler(&writableInfo{LogLevel: "DEBUG"}, &writableInfo{LogLevel: "ERROR"}), "log level not supported")
+	assert.Equal(t, change{old: "DEBUG", new: "ERROR"}, <-changes)
+	// the update equal to the accepted configuration, i.e. the reverted one, is ignored
+	require.NoError(t, client.handler(&writableInfo{LogLevel: "DEBUG"}, &writableInfo{LogLevel: "DEBUG"}))
+	assert.Empty(t, changes)
+
+	client.errorHandler(errors.New("message bus failure"))
+	assert.EqualError(t, <-errorsReceived, "message bus failure")
+
+	cancel()
+	select {
+	case <-client.stopped:
+	case <-time.After(time.Second):
+		require.Fail(t, "the watch wasn't stopped")
+	}
+}
+
+func TestOnChangeNotFound(t *testing.T) {
+	client := &handlerWatcherClient{Client: &mocks.Client{}, err: &types.OperationError{Kind: types.ErrNotFound, Message: "not found"}}
+
+	err := OnChange(context.Background(), client, "Missing", func(old, new writableInfo) error { return nil }, nil, func() messaging.MessageClient { return nil })
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestOnChangeNotSupported(t *testing.T) {
+	err := OnChange(context.Background(), &mocks.Client{}, "Writable", func(old, new writableInfo) error { return nil }, nil, func() messaging.MessageClient { return nil })
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/internal/pkg/keeper"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// KeeperProvider is the name of the built-in provider for EdgeX Core Keeper
+const KeeperProvider = "keeper"
+
+// ErrProviderRegistered is returned by RegisterProvider if a provider with the same name is already registered
+var ErrProviderRegistered = errors.New("configuration provider already registered")
+
+// ProviderFactory creates the Client of a configuration provider from the service's configuration
+type ProviderFactory func(config types.ServiceConfig) (Client, error)
+
+var (
+	providersMutex sync.RWMutex
+	providers      = map[string]ProviderFactory{
+		KeeperProvider: newKeeperClient,
+	}
+)
+
+// RegisterProvider makes the provider available to NewConfigurationClient for the ServiceConfig.Type name.
+// It is typically called from the init function of the package implementing the provider.
+func RegisterProvider(name string, factory ProviderFactory) error {
+	if name == "" {
+		return errors.New("unable to register configuration provider: name not set")
+	}
+	if factory == nil {
+		return fmt.Errorf("unable to register configuration provider '%s': factory not set", name)
+	}
+
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	if _, exists := providers[name]; exists {
+		return fmt.Errorf("%w: %s", ErrProviderRegistered, name)
+	}
+	providers[name] = factory
+	return nil
+}
+
+// Providers returns the sorted names of the registered providers
+func Providers() []string {
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupProvider(name string) (ProviderFactory, bool) {
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+	factory, exists := providers[name]
+	return factory, exists
+}
+
+func newKeeperClient(config types.ServiceConfig) (Client, error) {
+	client, err := keeper.NewKeeperClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,80 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configuration
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+func unregisterProvider(name string) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	delete(providers, name)
+}
+
+func TestRegisterProvider(t *testing.T) {
+	mockClient := &mocks.Client{}
+	var received types.ServiceConfig
+	factory := func(config types.ServiceConfig) (Client, error) {
+		received = config
+		return mockClient, nil
+	}
+	require.NoError(t, RegisterProvider("test", factory))
+	defer unregisterProvider("test")
+
+	assert.Equal(t, []string{KeeperProvider, "test"}, Providers())
+
+	err := RegisterProvider("test", factory)
+	assert.ErrorIs(t, err, ErrProviderRegistered)
+	err = RegisterProvider(KeeperProvider, factory)
+	assert.ErrorIs(t, err, ErrProviderRegistered)
+	assert.Error(t, RegisterProvider("", factory))
+	assert.Error(t, RegisterProvider("other", nil))
+
+	testConfig := types.ServiceConfig{Host: "localhost", Port: 8500, Type: "test", BasePath: "config"}
+	client, err := NewConfigurationClient(testConfig)
+	require.NoError(t, err)
+	assert.Same(t, mockClient, client)
+	assert.Equal(t, testConfig, received)
+}
+
+func TestRegisteredProviderFailure(t *testing.T) {
+	require.NoError(t, RegisterProvider("failing", func(types.ServiceConfig) (Client, error) {
+		return nil, errors.New("backend unavailable")
+	}))
+	defer unregisterProvider("failing")
+
+	_, err := NewConfigurationClient(types.ServiceConfig{Host: "localhost", Port: 8500, Type: "failing"})
+	assert.EqualError(t, err, "unable to create Configuration Client: backend unavailable")
+}
+
+func TestKeeperProviderFeatures(t *testing.T) {
+	client, err := newKeeperClient(types.ServiceConfig{Host: "localhost", Port: 59890, BasePath: "config"})
+	require.NoError(t, err)
+	defer client.(io.Closer).Close()
+
+	assert.Implements(t, (*FullPathReader)(nil), client)
+	assert.Implements(t, (*HandlerWatcher)(nil), client)
+	assert.Implements(t, (*FullPathWatcher)(nil), client)
+	assert.Implements(t, (*MultipleWatcher)(nil), client)
+	assert.Implements(t, (*HealthChecker)(nil), client)
+	assert.Implements(t, (*ReadinessWaiter)(nil), client)
+	assert.Implements(t, (*EntryReader)(nil), client)
+	assert.Implements(t, (*StoredEntryReader)(nil), client)
+	assert.Implements(t, (*ConditionalWriter)(nil), client)
+	assert.Implements(t, (*ValueDeleter)(nil), client)
+	assert.Implements(t, (*KeyRotator)(nil), client)
+	assert.Implements(t, (*CacheReporter)(nil), client)
+	assert.Implements(t, (*BasePathReporter)(nil), client)
+}
@@ -0,0 +1,185 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/spf13/cast"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+const bearerPrefix = "Bearer "
+
+// auditTrail reports the configuration changes to the Auditor, with the sensitive values redacted.
+// A nil auditTrail, which is used if no Auditor is configured, discards the changes.
+type auditTrail struct {
+	auditor  types.Auditor
+	injector interfaces.AuthenticationInjector
+	basePath string
+	patterns []string
+	logger   *clientLogger
+}
+
+func newAuditTrail(config types.ServiceConfig, logger *clientLogger) *auditTrail {
+	if config.Auditor == nil {
+		return nil
+	}
+	return &auditTrail{
+		auditor:  config.Auditor,
+		injector: config.AuthInjector,
+		basePath: config.BasePath,
+		patterns: config.SensitiveKeys,
+		logger:   logger,
+	}
+}
+
+// record reports the change of the key at keyPath, whose previous value is oldValue if it existed.
+// newValue is nil if the key was deleted.
+func (a *auditTrail) record(keyPath string, oldValue any, existed bool, newValue any) {
+	if a == nil {
+		return
+	}
+	event := types.AuditEvent{
+		Time:      time.Now(),
+		Actor:     a.actor(),
+		Operation: types.AuditPut,
+		Key:       keyPath,
+	}
+	if existed {
+		event.OldValue = a.redact(keyPath, oldValue)
+	}
+	if newValue == nil {
+		event.Operation = types.AuditDelete
+	} else {
+		event.NewValue = a.redact(keyPath, newValue)
+	}
+	if err := a.auditor.Audit(event); err != nil {
+		a.logger.warn("failed to audit the configuration change", "key", keyPath, "error", err)
+	}
+}
+
+// redact returns the value of the key at keyPath to record, which hides the sensitive and the encrypted values
+func (a *auditTrail) redact(keyPath string, value any) *string {
+	str := cast.ToString(value)
+	if isEncrypted(value) || matchesAny(a.patterns, strings.TrimPrefix(keyPath, a.basePath+KeyDelimiter)) {
+		str = redactedValue
+	}
+	return &str
+}
+
+// actor returns the identity given by the AuthInjector, or the subject of the bearer JWT it adds to the requests
+func (a *auditTrail) actor() string {
+	if identifier, ok := a.injector.(types.ActorIdentifier); ok {
+		return identifier.ActorIdentity()
+	}
+	if a.injector == nil {
+		return ""
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost", nil)
+	if err != nil || a.injector.AddAuthenticationData(req) != nil {
+		return ""
+	}
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), bearerPrefix)
+	if !found {
+		return ""
+	}
+	return jwtSubject(token)
+}
+
+// jwtSubject returns the subject claim of a JWT, without verifying it since it's only used to identify the actor
+func jwtSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+// auditConfiguration reports the change of the values of config, which was written under the base path with the
+// previous values oldValues
+func (k *keeperClient) auditConfiguration(oldValues map[string]any, config any) {
+	if k.audit == nil {
+		return
+	}
+	if raw, ok := config.(string); ok {
+		// Core Keeper flattens the JSON objects written as is, other values are stored at the base path
+		var configMap map[string]any
+		if json.Unmarshal([]byte(raw), &configMap) != nil {
+			oldValue, existed := oldValues[k.configBasePath]
+			k.audit.record(k.configBasePath, oldValue, existed, raw)
+			return
+		}
+		config = configMap
+	}
+	configMap, err := toConfigurationMap(config)
+	if err != nil {
+		k.logger.warn("failed to audit the configuration change", "key", k.configBasePath, "error", err)
+		return
+	}
+	pairs := convertInterfaceToPairs("", configMap)
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	for _, pair := range pairs {
+		keyPath := k.fullPath(pair.Key)
+		oldValue, existed := oldValues[keyPath]
+		k.audit.record(keyPath, oldValue, existed, pair.Value)
+	}
+}
+
+// auditDeletion reports the deletion of the keys with the previous values oldValues
+func (k *keeperClient) auditDeletion(oldValues map[string]any) {
+	keys := make([]string, 0, len(oldValues))
+	for key := range oldValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		k.audit.record(key, oldValues[key], true, nil)
+	}
+}
+
+// auditedValues returns the values stored at keyPath, and under it if deep, to record their change.
+// Only the value of keyPath itself is retrieved if not deep, and nothing if auditing is disabled.
+func (k *keeperClient) auditedValues(ctx context.Context, keyPath string, deep bool) map[string]any {
+	if k.audit == nil {
+		return nil
+	}
+	values := make(map[string]any)
+	if !deep {
+		if kv, found, err := k.storedPair(ctx, keyPath); err == nil && found {
+			values[keyPath] = kv.Value
+		}
+		return values
+	}
+	resp, err := k.kvsClient.ValuesByKey(ctx, keyPath)
+	if err != nil {
+		return values
+	}
+	for _, kv := range resp.Response {
+		if kv.Key == keyPath || strings.HasPrefix(kv.Key, keyPath+KeyDelimiter) {
+			values[kv.Key] = kv.Value
+		}
+	}
+	return values
+}
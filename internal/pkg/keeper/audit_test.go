@@ -0,0 +1,163 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAuditor struct {
+	mutex  sync.Mutex
+	events []types.AuditEvent
+	err    error
+}
+
+func (r *recordingAuditor) Audit(event types.AuditEvent) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+	return r.err
+}
+
+// changes returns the recorded events without their time, which is checked separately
+func (r *recordingAuditor) changes(t *testing.T) []types.AuditEvent {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	changes := make([]types.AuditEvent, len(r.events))
+	for i, event := range r.events {
+		assert.False(t, event.Time.IsZero())
+		event.Time = time.Time{}
+		changes[i] = event
+	}
+	r.events = nil
+	return changes
+}
+
+type bearerAuthenticationInjector struct {
+	token string
+}
+
+func (b *bearerAuthenticationInjector) AddAuthenticationData(req *http.Request) error {
+	req.Header.Set("Authorization", bearerPrefix+b.token)
+	return nil
+}
+
+func (b *bearerAuthenticationInjector) RoundTripper() http.RoundTripper {
+	return nil
+}
+
+type identifiedAuthenticationInjector struct {
+	emptyAuthenticationInjector
+}
+
+func (identifiedAuthenticationInjector) ActorIdentity() string {
+	return "operator"
+}
+
+func makeToken(payload string) string {
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+}
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestAuditTrail(t *testing.T) {
+	auditor := &recordingAuditor{}
+	basePath := getUniqueServiceName()
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:          testHost,
+		Port:          port,
+		BasePath:      basePath,
+		AuthInjector:  &bearerAuthenticationInjector{token: makeToken(`{"sub":"core-command","exp":1}`)},
+		SensitiveKeys: []string{"Writable/InsecureSecrets/*/Password"},
+		Auditor:       auditor,
+	})
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("INFO")))
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	assert.Equal(t, []types.AuditEvent{
+		{Actor: "core-command", Operation: types.AuditPut, Key: basePath + "/Writable/LogLevel", NewValue: ptr("INFO")},
+		{Actor: "core-command", Operation: types.AuditPut, Key: basePath + "/Writable/LogLevel", OldValue: ptr("INFO"), NewValue: ptr("DEBUG")},
+	}, auditor.changes(t))
+
+	// the keys left unchanged aren't recorded, and the sensitive values are redacted
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO", "InsecureSecrets": map[string]any{"DB": map[string]any{"Password": "secret"}}},
+	}, false))
+	assert.Equal(t, []types.AuditEvent{
+		{Actor: "core-command", Operation: types.AuditPut, Key: basePath + "/Writable/InsecureSecrets/DB/Password", NewValue: ptr(redactedValue)},
+	}, auditor.changes(t))
+
+	require.NoError(t, client.PutConfiguration(map[string]any{"Writable": map[string]any{"LogLevel": "WARN"}, "Port": 8080}, true))
+	assert.Equal(t, []types.AuditEvent{
+		{Actor: "core-command", Operation: types.AuditPut, Key: basePath + "/Port", NewValue: ptr("8080")},
+		{Actor: "core-command", Operation: types.AuditPut, Key: basePath + "/Writable/LogLevel", OldValue: ptr("DEBUG"), NewValue: ptr("WARN")},
+	}, auditor.changes(t))
+
+	require.NoError(t, client.DeleteConfigurationValue("Writable", true))
+	assert.Equal(t, []types.AuditEvent{
+		{Actor: "core-command", Operation: types.AuditDelete, Key: basePath + "/Writable/InsecureSecrets/DB/Password", OldValue: ptr(redactedValue)},
+		{Actor: "core-command", Operation: types.AuditDelete, Key: basePath + "/Writable/LogLevel", OldValue: ptr("WARN")},
+	}, auditor.changes(t))
+
+	// the failed writes aren't recorded
+	assert.Error(t, client.DeleteConfigurationValue("Writable", false))
+	assert.Empty(t, auditor.changes(t))
+
+	// a write isn't reverted if it can't be recorded
+	auditor.err = errors.New("disk full")
+	require.NoError(t, client.PutConfigurationValue("Port", []byte("8081")))
+	assert.Len(t, auditor.changes(t), 1)
+	assert.True(t, configValueExists("Port", client))
+}
+
+func TestAuditTrailActor(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   types.ServiceConfig
+		expected string
+	}{
+		{"identified", types.ServiceConfig{AuthInjector: &identifiedAuthenticationInjector{}}, "operator"},
+		{"JWT", types.ServiceConfig{AuthInjector: &bearerAuthenticationInjector{token: makeToken(`{"sub":"core-data"}`)}}, "core-data"},
+		{"invalid JWT", types.ServiceConfig{AuthInjector: &bearerAuthenticationInjector{token: "token"}}, ""},
+		{"invalid claims", types.ServiceConfig{AuthInjector: &bearerAuthenticationInjector{token: makeToken(`[]`)}}, ""},
+		{"no token", types.ServiceConfig{AuthInjector: NewNullAuthenticationInjector()}, ""},
+		{"no injector", types.ServiceConfig{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Auditor = &recordingAuditor{}
+			audit := newAuditTrail(test.config, nil)
+			assert.Equal(t, test.expected, audit.actor())
+		})
+	}
+}
+
+func TestAuditTrailDisabled(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	assert.Nil(t, client.audit)
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("INFO")))
+	require.NoError(t, client.DeleteConfigurationValue("Writable/LogLevel", false))
+}
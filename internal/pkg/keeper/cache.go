@@ -0,0 +1,170 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// cachedConfiguration is the content of the local cache file
+type cachedConfiguration struct {
+	BasePath string       `json:"basePath"`
+	CachedAt time.Time    `json:"cachedAt"`
+	Pairs    []models.KVS `json:"pairs"`
+}
+
+// configCache keeps the last good key space of the configBasePath on disk, so the configuration can still be
+// loaded while Core Keeper is unreachable. The values are cached as stored, so encrypted values remain encrypted.
+type configCache struct {
+	basePath        string
+	filePath        string
+	refreshInterval time.Duration
+
+	mutex      sync.Mutex
+	status     types.CacheStatus
+	refreshing bool
+	// closed stops the refresh loop once the client is closed
+	closed chan struct{}
+}
+
+func newConfigCache(dir string, basePath string, refreshInterval time.Duration) *configCache {
+	if dir == "" {
+		return nil
+	}
+	fileName := strings.ReplaceAll(strings.Trim(basePath, KeyDelimiter), KeyDelimiter, "_") + ".json"
+	return &configCache{
+		basePath:        basePath,
+		filePath:        filepath.Join(dir, fileName),
+		refreshInterval: refreshInterval,
+		status:          types.CacheStatus{Enabled: true},
+		closed:          make(chan struct{}),
+	}
+}
+
+// save writes pairs to the cache file and marks the cache as fresh
+func (c *configCache) save(pairs []models.KVS) error {
+	cached := cachedConfiguration{
+		BasePath: c.basePath,
+		CachedAt: time.Now(),
+		Pairs:    pairs,
+	}
+	bytes, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to encode the configuration cache: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(c.filePath), 0700); err != nil {
+		return fmt.Errorf("failed to create the configuration cache directory: %v", err)
+	}
+
+	// write to a temporary file first, so a crash never leaves a partially written cache behind
+	tmpPath := c.filePath + ".tmp"
+	if err = os.WriteFile(tmpPath, bytes, 0600); err != nil {
+		return fmt.Errorf("failed to write the configuration cache: %v", err)
+	}
+	if err = os.Rename(tmpPath, c.filePath); err != nil {
+		return fmt.Errorf("failed to write the configuration cache: %v", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.status.Stale = false
+	c.status.CachedAt = cached.CachedAt
+	c.status.LastError = ""
+	return nil
+}
+
+// load reads the cached pairs and marks the cache as stale because of cause
+func (c *configCache) load(cause error) ([]models.KVS, error) {
+	bytes, err := os.ReadFile(c.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration cache: %v", err)
+	}
+	var cached cachedConfiguration
+	if err = json.Unmarshal(bytes, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode the configuration cache: %v", err)
+	}
+	if cached.BasePath != c.basePath {
+		return nil, fmt.Errorf("the configuration cache %s belongs to %s rather than %s", c.filePath, cached.BasePath, c.basePath)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.status.Stale = true
+	c.status.CachedAt = cached.CachedAt
+	c.status.LastError = cause.Error()
+	return cached.Pairs, nil
+}
+
+func (c *configCache) getStatus() types.CacheStatus {
+	if c == nil {
+		return types.CacheStatus{}
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.status
+}
+
+// startRefresh periodically calls refresh until it succeeds or the cache is closed. Only one refresh loop runs at a
+// time.
+func (c *configCache) startRefresh(refresh func() bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.refreshing || c.isClosed() {
+		return
+	}
+	c.refreshing = true
+
+	go func() {
+		defer func() {
+			c.mutex.Lock()
+			c.refreshing = false
+			c.mutex.Unlock()
+		}()
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.closed:
+				return
+			case <-ticker.C:
+				if refresh() {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// close stops the refresh loop, if any, and prevents new ones from starting
+func (c *configCache) close() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.isClosed() {
+		close(c.closed)
+	}
+}
+
+func (c *configCache) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
@@ -6,12 +6,17 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"path"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
@@ -19,6 +24,7 @@ import (
 	httpClient "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/http"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
 	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
 
@@ -36,72 +42,261 @@ type keeperClient struct {
 	keeperUrl      string
 	configBasePath string
 	watchingDone   chan bool
+	// closed is closed by Close, dropping the errors of the watches which failed to start that weren't read
+	closed        chan struct{}
+	closeOnce     sync.Once
+	crypter       *valueCrypter
+	cache         *configCache
+	activity      *activityTracker
+	metrics       types.MetricsRecorder
+	tracer        *spanTracer
+	logger        *clientLogger
+	watchDebounce time.Duration
+	delivery      deliveryPolicy
+	// resubscribePolicy is the backoff between the attempts to recover a lost watch subscription, nil if disabled
+	resubscribePolicy *retryPolicy
+	// watchRevert enables reverting the rejected configuration changes in Core Keeper
+	watchRevert bool
+	audit       *auditTrail
 
 	commonClient interfaces.CommonClient
 	kvsClient    interfaces.KVSClient
 }
 
 // NewKeeperClient creates a new Keeper Client.
-func NewKeeperClient(config types.ServiceConfig) *keeperClient {
+func NewKeeperClient(config types.ServiceConfig) (*keeperClient, error) {
+	cacheDir, err := config.GetOptionalString(types.CacheDirOption, "")
+	if err != nil {
+		return nil, err
+	}
+	refreshInterval, err := config.GetOptionalDuration(types.CacheRefreshIntervalOption, types.DefaultCacheRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	if refreshInterval <= 0 {
+		return nil, fmt.Errorf("invalid value for optional property %s: %s is not positive", types.CacheRefreshIntervalOption, refreshInterval)
+	}
+	policy, err := newRetryPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	breaker, err := newCircuitBreaker(config)
+	if err != nil {
+		return nil, err
+	}
+	watchDebounce, err := config.GetOptionalDuration(types.WatchDebounceOption, 0)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := newDeliveryPolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	resubscribePolicy, err := newResubscribePolicy(config)
+	if err != nil {
+		return nil, err
+	}
+	watchRevert, err := config.GetOptionalBool(types.WatchRevertRejectedOption, false)
+	if err != nil {
+		return nil, err
+	}
+
 	client := keeperClient{
-		keeperUrl:      config.GetUrl(),
-		configBasePath: config.BasePath,
-		watchingDone:   make(chan bool, 1),
+		keeperUrl:         config.GetUrl(),
+		configBasePath:    config.BasePath,
+		watchingDone:      make(chan bool, 1),
+		closed:            make(chan struct{}),
+		crypter:           newValueCrypter(config.KeyProvider, config.SensitiveKeys),
+		cache:             newConfigCache(cacheDir, config.BasePath, refreshInterval),
+		activity:          &activityTracker{},
+		metrics:           config.Metrics,
+		tracer:            newSpanTracer(config.Tracer, config.BasePath),
+		logger:            newClientLogger(config.Logger, config.BasePath, config.SensitiveKeys),
+		watchDebounce:     watchDebounce,
+		delivery:          delivery,
+		resubscribePolicy: resubscribePolicy,
+		watchRevert:       watchRevert,
+	}
+	client.audit = newAuditTrail(config, client.logger)
+
+	// Create the common and KVS http clients for invoking APIs from Keeper,
+	// which share the retry policy and the circuit breaker
+	caller := resilientCaller{policy: policy, breaker: breaker}
+	authInjector := withLogging(withTracing(config.AuthInjector, client.tracer), client.logger)
+	client.commonClient = &resilientCommonClient{
+		resilientCaller: caller,
+		client:          httpClient.NewCommonClient(client.keeperUrl, authInjector),
 	}
+	client.kvsClient = &trackingKVSClient{
+		activity: client.activity,
+		client: &resilientKVSClient{
+			resilientCaller: caller,
+			client:          httpClient.NewKVSClient(client.keeperUrl, authInjector),
+		},
+	}
+	return &client, nil
+}
 
-	// Create the common and KVS http clients for invoking APIs from Keeper
-	client.commonClient = httpClient.NewCommonClient(client.keeperUrl, config.AuthInjector)
-	client.kvsClient = httpClient.NewKVSClient(client.keeperUrl, config.AuthInjector)
-	return &client
+// BasePath returns the base path the keys are relative to
+func (k *keeperClient) BasePath() string {
+	return k.configBasePath
 }
 
 func (k *keeperClient) fullPath(name string) string {
 	return path.Join(k.configBasePath, name)
 }
 
+// basePathOf returns the base path of the service whose configuration fullPath belongs to, i.e. configBasePath or the
+// leading segments of fullPath as many as the ones of configBasePath, against which its value references are resolved
+func (k *keeperClient) basePathOf(fullPath string) string {
+	if fullPath == k.configBasePath || strings.HasPrefix(fullPath, k.configBasePath+KeyDelimiter) {
+		return k.configBasePath
+	}
+	depth := len(strings.Split(strings.Trim(k.configBasePath, KeyDelimiter), KeyDelimiter))
+	segments := strings.Split(fullPath, KeyDelimiter)
+	if len(segments) <= depth {
+		return fullPath
+	}
+	return strings.Join(segments[:depth], KeyDelimiter)
+}
+
 // IsAlive simply checks if Core Keeper is up and running at the configured URL
 func (k *keeperClient) IsAlive() bool {
-	if _, err := k.commonClient.Ping(context.Background()); err != nil {
+	ctx, end := k.tracer.start(context.Background(), "IsAlive", k.configBasePath)
+	err := k.ping(ctx)
+	end(err)
+	return err == nil
+}
+
+func (k *keeperClient) ping(ctx context.Context) error {
+	if _, err := k.commonClient.Ping(ctx); err != nil {
+		return newOperationError(k.configBasePath, err, "unable to ping Core Keeper at %s", k.keeperUrl)
+	}
+	return nil
+}
+
+// WaitUntilReady blocks until Core Keeper responds to ping and, if required by the policy, contains the service's
+// configuration. A *types.ReadinessError describing the last completed check is returned if ctx expires first, even
+// while a check is still waiting for Core Keeper.
+func (k *keeperClient) WaitUntilReady(ctx context.Context, policy types.ReadinessPolicy) (err error) {
+	ctx, end := k.tracer.start(ctx, "WaitUntilReady", k.configBasePath)
+	defer func() { end(err) }()
+
+	interval := policy.Interval
+	if interval <= 0 {
+		interval = types.DefaultReadinessInterval
+	}
+
+	start := time.Now()
+	readinessErr := &types.ReadinessError{
+		Url:      k.keeperUrl,
+		BasePath: k.configBasePath,
+	}
+	for {
+		readinessErr.Attempts++
+		// the check is made on its own goroutine, into a copy of readinessErr, as the requests to Core Keeper don't
+		// honor ctx, so that a hanging request doesn't outlive ctx
+		outcome := *readinessErr
+		ready := make(chan bool, 1)
+		go func() {
+			ready <- k.checkReadiness(ctx, policy, &outcome)
+		}()
+		select {
+		case <-ctx.Done():
+			readinessErr.Elapsed = time.Since(start)
+			readinessErr.Cause = ctx.Err()
+			return readinessErr
+		case isReady := <-ready:
+			if isReady {
+				return nil
+			}
+			readinessErr = &outcome
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			readinessErr.Elapsed = time.Since(start)
+			readinessErr.Cause = ctx.Err()
+			return readinessErr
+		case <-timer.C:
+		}
+	}
+}
+
+// checkReadiness does a single readiness check and records its outcome into readinessErr
+func (k *keeperClient) checkReadiness(ctx context.Context, policy types.ReadinessPolicy, readinessErr *types.ReadinessError) bool {
+	readinessErr.PingError = ""
+	readinessErr.ConfigurationError = ""
+	readinessErr.ConfigurationMissing = false
+
+	if _, err := k.commonClient.Ping(ctx); err != nil {
+		readinessErr.Reachable = false
+		readinessErr.PingError = err.Error()
 		return false
 	}
-	return true
+	readinessErr.Reachable = true
+	if !policy.WaitForConfiguration {
+		return true
+	}
+
+	exists, err := k.hasConfiguration(ctx)
+	if err != nil {
+		readinessErr.ConfigurationError = err.Error()
+		return false
+	}
+	readinessErr.ConfigurationMissing = !exists
+	return exists
 }
 
 // HasConfiguration checks to see if Core Keeper contains the service's configuration.
-func (k *keeperClient) HasConfiguration() (bool, error) {
-	_, err := k.kvsClient.ListKeys(context.Background(), k.configBasePath)
+func (k *keeperClient) HasConfiguration() (exists bool, err error) {
+	ctx, end := k.tracer.start(context.Background(), "HasConfiguration", k.configBasePath)
+	defer func() { end(err) }()
+	return k.hasConfiguration(ctx)
+}
+
+func (k *keeperClient) hasConfiguration(ctx context.Context) (bool, error) {
+	_, err := k.kvsClient.ListKeys(ctx, k.configBasePath)
 	if err != nil {
 		if err.Code() == http.StatusNotFound {
 			return false, nil
 		}
-		return false, fmt.Errorf("checking configuration existence from Core Keeper failed: %v", err)
+		return false, newOperationError(k.configBasePath, err, "checking configuration existence from Core Keeper failed")
 	}
 	return true, nil
 }
 
 // HasSubConfiguration checks to see if the Configuration service contains the service's sub configuration.
-func (k *keeperClient) HasSubConfiguration(name string) (bool, error) {
+func (k *keeperClient) HasSubConfiguration(name string) (exists bool, err error) {
 	keyPath := k.fullPath(name)
-	_, err := k.kvsClient.ListKeys(context.Background(), keyPath)
-	if err != nil {
-		if err.Code() == http.StatusNotFound {
+	ctx, end := k.tracer.start(context.Background(), "HasSubConfiguration", keyPath)
+	defer func() { end(err) }()
+
+	_, edgexErr := k.kvsClient.ListKeys(ctx, keyPath)
+	if edgexErr != nil {
+		if edgexErr.Code() == http.StatusNotFound {
 			return false, nil
 		}
-		return false, fmt.Errorf("checking sub configuration existence from Core Keeper failed: %v", err)
+		return false, newOperationError(keyPath, edgexErr, "checking sub configuration existence from Core Keeper failed")
 	}
 	return true, nil
 }
 
 // PutConfigurationMap puts a full configuration map into Core Keeper.
 // The sub-paths to where the values are to be stored in Core Keeper are generated from the map key.
-func (k *keeperClient) PutConfigurationMap(configuration map[string]any, overwrite bool) error {
+func (k *keeperClient) PutConfigurationMap(configuration map[string]any, overwrite bool) (err error) {
+	ctx, end := k.tracer.start(context.Background(), "PutConfigurationMap", k.configBasePath)
+	defer func() { end(err) }()
+
 	keyValues := convertInterfaceToPairs("", configuration)
 
 	// Put config properties into Core Keeper.
 	for _, keyValue := range keyValues {
-		exists, _ := k.ConfigurationValueExists(keyValue.Key)
+		exists, _ := k.configurationValueExists(ctx, keyValue.Key)
 		if !exists || overwrite {
-			if err := k.PutConfigurationValue(keyValue.Key, []byte(keyValue.Value)); err != nil {
+			if err := k.putConfigurationValue(ctx, keyValue.Key, []byte(keyValue.Value)); err != nil {
 				return err
 			}
 		}
@@ -110,9 +305,19 @@ func (k *keeperClient) PutConfigurationMap(configuration map[string]any, overwri
 	return nil
 }
 
-// PutConfiguration puts a full configuration struct into the Configuration provider
-func (k *keeperClient) PutConfiguration(config interface{}, overwrite bool) error {
-	var err error
+// PutConfiguration puts a full configuration struct into the Configuration provider. Unless overwrite is true, the
+// struct is converted to a map and put key by key, creating only the keys which don't exist yet.
+func (k *keeperClient) PutConfiguration(config interface{}, overwrite bool) (err error) {
+	ctx, end := k.tracer.start(context.Background(), "PutConfiguration", k.configBasePath)
+	defer func() { end(err) }()
+
+	if k.crypter != nil {
+		// encrypt the sensitive values before they leave the client
+		if config, err = k.crypter.encryptConfiguration(config); err != nil {
+			return err
+		}
+	}
+
 	if overwrite {
 		value := config
 		if byteArray, ok := config.([]byte); ok {
@@ -121,213 +326,695 @@ func (k *keeperClient) PutConfiguration(config interface{}, overwrite bool) erro
 		request := requests.UpdateKeysRequest{
 			Value: value,
 		}
-		_, err = k.kvsClient.UpdateValuesByKey(context.Background(), k.configBasePath, true, request)
+		oldValues := k.auditedValues(ctx, k.configBasePath, true)
+		_, edgexErr := k.kvsClient.UpdateValuesByKey(ctx, k.configBasePath, true, request)
+		if edgexErr != nil {
+			return newOperationError(k.configBasePath, edgexErr, "error occurred while creating/updating configuration, error")
+		}
+		k.auditConfiguration(oldValues, value)
 	} else {
+		switch config.(type) {
+		case []byte, string:
+		default:
+			// convert a struct to a map, so that its keys are created one by one
+			if config, err = toConfigurationMap(config); err != nil {
+				return fmt.Errorf("failed to convert the configuration: %v", err)
+			}
+		}
 		kvPairs := convertInterfaceToPairs("", config)
 		for _, kv := range kvPairs {
-			exists, err := k.ConfigurationValueExists(kv.Key)
+			exists, err := k.configurationValueExists(ctx, kv.Key)
 			if err != nil {
 				return err
 			}
 			if !exists {
 				// Only create the key if not exists in core keeper
-				if err = k.PutConfigurationValue(kv.Key, []byte(kv.Value)); err != nil {
+				if err = k.putConfigurationValue(ctx, kv.Key, []byte(kv.Value)); err != nil {
 					return err
 				}
 			}
 		}
 	}
-	if err != nil {
-		return fmt.Errorf("error occurred while creating/updating configuration, error: %v", err)
-	}
 	return nil
 }
 
 // GetConfiguration gets the full configuration from Core Keeper into the target configuration struct.
 // Passed in struct is only a reference for decoder, empty struct is ok
 // Returns the configuration in the target struct as interface{}, which caller must cast
-func (k *keeperClient) GetConfiguration(configStruct interface{}) (interface{}, error) {
-	exists, err := k.HasConfiguration()
+// If the local cache is enabled and Core Keeper is unreachable, the last good configuration is loaded from the cache.
+func (k *keeperClient) GetConfiguration(configStruct interface{}) (_ interface{}, err error) {
+	ctx, end := k.tracer.start(context.Background(), "GetConfiguration", k.configBasePath)
+	defer func() { end(err) }()
+
+	pairs, fromCache, err := k.loadConfigurationPairs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if !exists {
-		return nil, fmt.Errorf("the Configuration service (EdgeX Keeper) doesn't contain configuration for %s", k.configBasePath)
+	if err = k.decodeConfiguration(ctx, k.configBasePath, pairs, configStruct); err != nil {
+		return nil, err
 	}
 
-	resp, err := k.kvsClient.ValuesByKey(context.Background(), k.configBasePath)
-	if err != nil {
-		return nil, err
+	if k.cache != nil && !fromCache {
+		// the cache is only updated once the configuration is decoded successfully
+		_ = k.cache.save(pairs)
 	}
+	return configStruct, nil
+}
 
-	err = decode(k.configBasePath+KeyDelimiter, resp.Response, configStruct)
-	if err != nil {
+// GetConfigurationByFullPath gets the configuration under the full path from Core Keeper into the target configuration
+// struct, i.e. the common configuration stored under the path of another service.
+// Unlike GetConfiguration, the configuration is never loaded from the local cache.
+func (k *keeperClient) GetConfigurationByFullPath(configStruct interface{}, fullPath string) (_ interface{}, err error) {
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationByFullPath", fullPath)
+	defer func() { end(err) }()
+	return k.getConfigurationByFullPath(ctx, configStruct, fullPath)
+}
+
+func (k *keeperClient) getConfigurationByFullPath(ctx context.Context, configStruct interface{}, fullPath string) (interface{}, error) {
+	resp, edgexErr := k.kvsClient.ValuesByKey(ctx, fullPath)
+	if edgexErr != nil {
+		return nil, newOperationError(fullPath, edgexErr, "unable to get configuration for %s from Core Keeper", fullPath)
+	}
+	if err := k.decodeConfiguration(ctx, fullPath, resp.Response, configStruct); err != nil {
 		return nil, err
 	}
 	return configStruct, nil
 }
 
-func (k *keeperClient) WatchForChanges(updateChannel chan<- interface{}, errorChannel chan<- error, configuration interface{}, waitKey string, getMsgClientCb func() messaging.MessageClient) {
-	messageClient := getMsgClientCb()
-	if messageClient == nil {
-		configErr := errors.New("unable to use MessageClient to watch for configuration changes")
-		errorChannel <- configErr
-		return
+// decodeConfiguration resolves the value references of the key-value pairs under keyPath against the base path keyPath
+// belongs to, and decodes them into configStruct
+func (k *keeperClient) decodeConfiguration(ctx context.Context, keyPath string, pairs []models.KVS, configStruct interface{}) error {
+	interpolated, err := k.interpolate(ctx, k.basePathOf(keyPath), pairs)
+	if err != nil {
+		return &types.DecodeError{KeyPath: keyPath, Err: err}
 	}
 
-	messages := make(chan msgTypes.MessageEnvelope)
-	topic := path.Join(keeperTopicPrefix, k.configBasePath, waitKey, "#")
-	topics := []msgTypes.TopicChannel{
-		{
-			Topic:    topic,
-			Messages: messages,
-		},
+	err = decode(keyPath+KeyDelimiter, interpolated, configStruct, k.crypter)
+	if err != nil {
+		k.logger.debug("failed to decode the configuration", "keyPath", keyPath, "error", k.logger.redactError(err))
+		return err
 	}
+	return nil
+}
 
-	watchErrors := make(chan error)
-	err := messageClient.Subscribe(topics, watchErrors)
+// loadConfigurationPairs gets all the key-value pairs under configBasePath from Core Keeper,
+// falling back to the local cache if Core Keeper is unreachable
+func (k *keeperClient) loadConfigurationPairs(ctx context.Context) ([]models.KVS, bool, error) {
+	pairs, unreachable, err := k.fetchConfigurationPairs(ctx)
+	if err == nil || !unreachable || k.cache == nil {
+		return pairs, false, err
+	}
+
+	cached, cacheErr := k.cache.load(err)
+	if cacheErr != nil {
+		return nil, false, fmt.Errorf("%w, and the fallback to the local cache failed: %v", err, cacheErr)
+	}
+	k.cache.startRefresh(k.refreshCache)
+	return cached, true, nil
+}
+
+// fetchConfigurationPairs gets all the key-value pairs under configBasePath from Core Keeper.
+// The returned bool indicates if the error was caused by Core Keeper being unavailable.
+func (k *keeperClient) fetchConfigurationPairs(ctx context.Context) ([]models.KVS, bool, error) {
+	if k.cache != nil && k.ping(ctx) != nil {
+		return nil, true, &types.OperationError{
+			Kind:    types.ErrUnavailable,
+			KeyPath: k.configBasePath,
+			Message: fmt.Sprintf("the Configuration service (EdgeX Keeper) is unreachable at %s", k.keeperUrl),
+		}
+	}
+
+	exists, err := k.hasConfiguration(ctx)
 	if err != nil {
-		_ = messageClient.Disconnect()
-		errorChannel <- err
-		return
+		return nil, errors.Is(err, types.ErrUnavailable), err
 	}
 
-	go func() {
-		defer func() {
-			_ = messageClient.Disconnect()
-		}()
+	if !exists {
+		return nil, false, &types.OperationError{
+			Kind:    types.ErrNotFound,
+			KeyPath: k.configBasePath,
+			Message: fmt.Sprintf("the Configuration service (EdgeX Keeper) doesn't contain configuration for %s", k.configBasePath),
+		}
+	}
 
-		// send a nil value to updateChannel once the watcher connection is established
-		// for go-mod-bootstrap to ignore the first change event
-		// refer to the isFirstUpdate variable declared in https://github.com/edgexfoundry/go-mod-bootstrap/blob/main/bootstrap/config/config.go
-		updateChannel <- nil
+	resp, edgexErr := k.kvsClient.ValuesByKey(ctx, k.configBasePath)
+	if edgexErr != nil {
+		err = newOperationError(k.configBasePath, edgexErr, "unable to get configuration for %s from Core Keeper", k.configBasePath)
+		return nil, errors.Is(err, types.ErrUnavailable), err
+	}
+	return resp.Response, false, nil
+}
 
-	outerLoop:
+// refreshCache updates the local cache once Core Keeper is reachable again
+func (k *keeperClient) refreshCache() bool {
+	if k.ping(context.Background()) != nil {
+		return false
+	}
+	resp, err := k.kvsClient.ValuesByKey(context.Background(), k.configBasePath)
+	if err != nil {
+		return false
+	}
+	return k.cache.save(resp.Response) == nil
+}
+
+// CacheStatus returns where the configuration returned by the last GetConfiguration came from
+func (k *keeperClient) CacheStatus() types.CacheStatus {
+	return k.cache.getStatus()
+}
+
+// Close stops the background refresh of the local cache, and drops the errors of the watches which failed to start
+// that weren't read. The watches are stopped with StopWatching, or with the function returned by
+// WatchForChangesWithHandler.
+func (k *keeperClient) Close() error {
+	k.cache.close()
+	k.closeOnce.Do(func() { close(k.closed) })
+	return nil
+}
+
+func (k *keeperClient) WatchForChanges(updateChannel chan<- interface{}, errorChannel chan<- error, configuration interface{}, waitKey string, getMsgClientCb func() messaging.MessageClient) {
+	k.WatchForChangesByFullPath(updateChannel, errorChannel, configuration, path.Join(k.configBasePath, waitKey), getMsgClientCb)
+}
+
+// WatchForChangesByFullPath watches the key given the full path like WatchForChanges, i.e. to watch the common
+// configuration stored under the path of another service
+func (k *keeperClient) WatchForChangesByFullPath(updateChannel chan<- interface{}, errorChannel chan<- error, configuration interface{}, fullPath string, getMsgClientCb func() messaging.MessageClient) {
+	target := watchTarget{
+		pattern:       fullPath,
+		configuration: configuration,
+		update: func(_ string, updated interface{}) interface{} {
+			return updated
+		},
+		revert: k.watchRevert,
+	}
+	// send a nil value to updateChannel once the watcher connection is established
+	// for go-mod-bootstrap to ignore the first change event
+	// refer to the isFirstUpdate variable declared in https://github.com/edgexfoundry/go-mod-bootstrap/blob/main/bootstrap/config/config.go
+	if err := k.startWatch(updateChannel, errorChannel, []watchTarget{target}, getMsgClientCb, k.watchingDone, true); err != nil {
+		k.sendWatchError(errorChannel, err)
+	}
+}
+
+// WatchForChangesWithHandler watches waitKey like WatchForChanges, but calls handler with the previous and the updated
+// configuration before each update is applied rather than sending the updates. The errors of the watch are passed to
+// errorHandler, if set, including the updates rejected by handler, which are reverted in Core Keeper.
+// Returns the function stopping this watch, which isn't stopped by StopWatching, or an error if the current
+// configuration can't be decoded into configuration or the watch can't start.
+func (k *keeperClient) WatchForChangesWithHandler(configuration interface{}, waitKey string, handler func(old, new interface{}) error, errorHandler func(error), getMsgClientCb func() messaging.MessageClient) (func(), error) {
+	keyPath := k.fullPath(waitKey)
+	if _, err := k.getConfigurationByFullPath(context.Background(), configuration, keyPath); err != nil {
+		return nil, err
+	}
+	target := watchTarget{
+		pattern:       keyPath,
+		configuration: configuration,
+		update: func(_ string, updated interface{}) interface{} {
+			return updated
+		},
+		validate: handler,
+		// the changes rejected by handler aren't kept by Core Keeper, nor by the other watches
+		revert: true,
+	}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	stop := make(chan bool)
+	if err := k.startWatch(updateChannel, errorChannel, []watchTarget{target}, getMsgClientCb, stop, false); err != nil {
+		k.activity.recordWatchError(err)
+		return nil, err
+	}
+	go func() {
 		for {
 			select {
-			case <-k.watchingDone:
+			case <-stop:
 				return
-			case e := <-watchErrors:
-				errorChannel <- e
-			case msgEnvelope := <-messages:
-				if msgEnvelope.ContentType != common.ContentTypeJSON && msgEnvelope.ContentType != common.ContentTypeCBOR {
-					errorChannel <- fmt.Errorf("invalid content type of configuration changes message, expected: %s or %s, but got: %s", common.ContentTypeJSON, common.ContentTypeCBOR, msgEnvelope.ContentType)
-					continue
-				}
-				var updatedConfig models.KVS
-				// unmarshal the updated config to KV DTO
-				updatedConfig, err := msgTypes.GetMsgPayload[models.KVS](msgEnvelope)
-				if err != nil {
-					errorChannel <- fmt.Errorf("failed to unmarshal the updated configuration: %v", err)
-					continue
+			case <-updateChannel:
+				// handler was already called with the update
+			case err := <-errorChannel:
+				if errorHandler != nil {
+					errorHandler(err)
 				}
-				keyPrefix := path.Join(k.configBasePath, waitKey)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(stop) }) }, nil
+}
 
-				// get the whole configs KV DTO array from Keeper with the same keyPrefix
-				kvConfigs, err := k.kvsClient.ValuesByKey(context.Background(), keyPrefix)
-				if err != nil {
-					errorChannel <- fmt.Errorf("failed to get the configurations with key prefix %s from Keeper: %v", keyPrefix, err)
-					continue
-				}
+// WatchForMultipleChanges watches the keys of targets over a single message bus subscription, and sends a
+// types.WatchUpdate on updateChannel once the configuration under one of them is updated
+func (k *keeperClient) WatchForMultipleChanges(updateChannel chan<- interface{}, errorChannel chan<- error, targets []types.WatchTarget, getMsgClientCb func() messaging.MessageClient) {
+	if len(targets) == 0 {
+		k.sendWatchError(errorChannel, errors.New("no key to watch for configuration changes"))
+		return
+	}
+	watchTargets := make([]watchTarget, len(targets))
+	for i, target := range targets {
+		watchTargets[i] = watchTarget{
+			pattern:       path.Join(k.configBasePath, target.WaitKey),
+			configuration: target.Configuration,
+			update: func(keyPrefix string, updated interface{}) interface{} {
+				return types.WatchUpdate{WaitKey: target.WaitKey, Key: keyPrefix, Configuration: updated}
+			},
+			revert: k.watchRevert,
+		}
+	}
+	if err := k.startWatch(updateChannel, errorChannel, watchTargets, getMsgClientCb, k.watchingDone, false); err != nil {
+		k.sendWatchError(errorChannel, err)
+	}
+}
 
-				// if the updated key not equal to keyPrefix, need to check the updated key and value from the message payload are valid
-				// e.g. keyPrefix = "edgex/3.0/core-data/Writable" which is the root level of Writable configuration
-				if updatedConfig.Key != keyPrefix {
-					for _, c := range kvConfigs.Response {
-						if c.Key == updatedConfig.Key {
-							// convert the updatedConfig.Value to string for value comparison, because the value retrieved from the Keeper is always a string, but the value from the message payload may be either a string, bool, or float.
-							// if the updated value in the message payload is different from the one obtained by Keeper
-							// skip this subscribed message payload and continue the outer loop
-							updatedValueStr := cast.ToString(updatedConfig.Value)
-							if c.Value != updatedValueStr {
-								continue outerLoop
-							}
-							break
-						}
-					}
-				}
+// parseWatchMessage returns the changed key-value pair carried by a change message
+func parseWatchMessage(msgEnvelope msgTypes.MessageEnvelope) (models.KVS, error) {
+	if msgEnvelope.ContentType != common.ContentTypeJSON && msgEnvelope.ContentType != common.ContentTypeCBOR {
+		return models.KVS{}, fmt.Errorf("invalid content type of configuration changes message, expected: %s or %s, but got: %s", common.ContentTypeJSON, common.ContentTypeCBOR, msgEnvelope.ContentType)
+	}
+	// unmarshal the updated config to KV DTO
+	updatedConfig, err := msgTypes.GetMsgPayload[models.KVS](msgEnvelope)
+	if err != nil {
+		return models.KVS{}, fmt.Errorf("failed to unmarshal the updated configuration: %v", err)
+	}
+	return updatedConfig, nil
+}
 
-				// note that the configuration will bare runtime values after first call to the
-				// WatchForChanges, so it's possible that a custom config that has been removed will
-				// still remain in the configuration after loading kvConfigs.Response into configuration.
-				// To avoid such cases, always reset configuration (a pointer to a struct) to its zero value
-				// before loading kvConfigs.Response into configuration.
-				v := reflect.ValueOf(configuration)
-				if v.Kind() == reflect.Ptr && !v.IsNil() {
-					v = v.Elem()
-					v.Set(reflect.Zero(v.Type()))
-				}
+// processWatchChanges decodes the configuration under keyPrefix into the configuration of target after change messages
+// are received.
+// Returns the number of changes which are current, the decoded configuration, which isn't modified by the watch
+// afterwards unlike the configuration of target, and the key-value pairs stored under keyPrefix. The configuration
+// isn't updated if all the changes are outdated by newer values in Core Keeper, or if the decoded configuration is
+// rejected by its types.ConfigurationValidator or by the validate function of target.
+func (k *keeperClient) processWatchChanges(ctx context.Context, changes []models.KVS, keyPrefix string, target *watchTarget) (int, interface{}, []models.KVS, error) {
+	configuration := target.configuration
+	setSpanAttributes(ctx, map[string]any{updatedKeyAttribute: changes[len(changes)-1].Key})
+	if len(changes) > 1 {
+		setSpanAttributes(ctx, map[string]any{coalescedAttribute: len(changes)})
+	}
+	for _, updatedConfig := range changes {
+		k.logger.trace("received configuration change", "key", updatedConfig.Key, "value", k.logger.redact(updatedConfig.Key, updatedConfig.Value))
+	}
 
-				// decode KV DTO array to configuration struct
-				err = decode(keyPrefix, kvConfigs.Response, configuration)
-				if err != nil {
-					errorChannel <- fmt.Errorf("failed to decode the updated configuration: %v", err)
-					continue
-				}
-				updateChannel <- configuration
+	// get the whole configs KV DTO array from Keeper with the same keyPrefix
+	kvConfigs, edgexErr := k.kvsClient.ValuesByKey(ctx, keyPrefix)
+	if edgexErr != nil {
+		return 0, nil, nil, newOperationError(keyPrefix, edgexErr, "failed to get the configurations with key prefix %s from Keeper", keyPrefix)
+	}
+
+	current := 0
+	for _, updatedConfig := range changes {
+		if !k.isOutdatedChange(updatedConfig, keyPrefix, kvConfigs.Response) {
+			current++
+		}
+	}
+	if current == 0 {
+		setSpanAttributes(ctx, map[string]any{staleAttribute: true})
+		return 0, nil, kvConfigs.Response, nil
+	}
+
+	// resolve the value references before decoding, so that the previous good configuration
+	// is kept if any of the references can't be resolved
+	pairs, err := k.interpolate(ctx, k.basePathOf(keyPrefix), kvConfigs.Response)
+	if err != nil {
+		return 0, nil, kvConfigs.Response, fmt.Errorf("failed to interpolate the updated configuration: %w", &types.DecodeError{KeyPath: keyPrefix, Err: err})
+	}
+
+	// note that the configuration will bare runtime values after first call to the
+	// WatchForChanges, so it's possible that a custom config that has been removed would
+	// still remain in the configuration after loading kvConfigs.Response into configuration.
+	// To avoid such cases, always decode kvConfigs.Response into a new zero value of
+	// configuration (a pointer to a struct), which is only copied into configuration once
+	// validated so that the previous good configuration is kept otherwise.
+	decoded := configuration
+	v := reflect.ValueOf(configuration)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		decoded = reflect.New(v.Elem().Type()).Interface()
+	}
+
+	// decode KV DTO array to configuration struct
+	err = decode(keyPrefix, pairs, decoded, k.crypter)
+	if err != nil {
+		return 0, nil, kvConfigs.Response, fmt.Errorf("failed to decode the updated configuration: %w", err)
+	}
+	if validator, ok := decoded.(types.ConfigurationValidator); ok {
+		if err = validator.Validate(); err != nil {
+			return 0, nil, kvConfigs.Response, &types.RejectedChangeError{KeyPath: keyPrefix, Err: err}
+		}
+	}
+	if target.validate != nil {
+		if err = target.validate(configuration, decoded); err != nil {
+			return 0, nil, kvConfigs.Response, &types.RejectedChangeError{KeyPath: keyPrefix, Err: err}
+		}
+	}
+	if decoded != configuration {
+		v.Elem().Set(reflect.ValueOf(decoded).Elem())
+	}
+	return current, decoded, kvConfigs.Response, nil
+}
+
+// isOutdatedChange checks if the value of a change message differs from the current value in Core Keeper, i.e. when
+// the key was updated again since
+func (k *keeperClient) isOutdatedChange(updatedConfig models.KVS, keyPrefix string, currentPairs []models.KVS) bool {
+	// if the updated key not equal to keyPrefix, need to check the updated key and value from the message payload are valid
+	// e.g. keyPrefix = "edgex/3.0/core-data/Writable" which is the root level of Writable configuration
+	if updatedConfig.Key == keyPrefix {
+		return false
+	}
+	for _, c := range currentPairs {
+		if c.Key == updatedConfig.Key {
+			// convert the updatedConfig.Value to string for value comparison, because the value retrieved from the Keeper is always a string, but the value from the message payload may be either a string, bool, or float.
+			// if the updated value in the message payload is different from the one obtained by Keeper
+			// skip this subscribed message payload
+			updatedValueStr := cast.ToString(updatedConfig.Value)
+			if c.Value != updatedValueStr {
+				k.logger.debug("skipping outdated configuration change", "key", c.Key,
+					"value", k.logger.redact(c.Key, updatedValueStr), "currentValue", k.logger.redact(c.Key, c.Value))
+				return true
 			}
+			return false
+		}
+	}
+	return false
+}
+
+// countWatchMessages reports the outcome of count watch messages to the metrics recorder, if any
+func (k *keeperClient) countWatchMessages(outcome types.WatchOutcome, count int) {
+	if k.metrics == nil {
+		return
+	}
+	for range count {
+		k.metrics.IncWatchMessages(outcome)
+	}
+}
+
+// observeWatchDecode reports the latency of the re-fetch and decode of a watch message to the metrics recorder, if any
+func (k *keeperClient) observeWatchDecode(start time.Time, err error) {
+	if k.metrics != nil {
+		k.metrics.ObserveOperation(types.WatchDecodeOperation, time.Since(start), types.ErrorKind(err))
+	}
+}
+
+// sendWatchError records the error of a watch failing to start for HealthCheck, and sends it on errorChannel from
+// another goroutine, as the caller may only read errorChannel once the watch call returned.
+// The error is dropped once the client is closed, rather than once StopWatching is called, which stops the watches
+// running.
+func (k *keeperClient) sendWatchError(errorChannel chan<- error, err error) {
+	k.activity.recordWatchError(err)
+	go func() {
+		select {
+		case errorChannel <- err:
+		case <-k.closed:
 		}
 	}()
 }
 
+// deliverWatchError records the watch error for HealthCheck and delivers it.
+// Returns false if the watch was stopped meanwhile.
+func (k *keeperClient) deliverWatchError(delivery *watchDelivery, err error) bool {
+	k.activity.recordWatchError(err)
+	return delivery.sendError(err)
+}
+
 // StopWatching causes all WatchForChanges processing to stop
 func (k *keeperClient) StopWatching() {
 	k.watchingDone <- true
 }
 
+// interpolate resolves the value references of the key-value pairs retrieved from Core Keeper, relative to basePath.
+// Referenced keys which are not part of pairs are looked up from Core Keeper.
+func (k *keeperClient) interpolate(ctx context.Context, basePath string, pairs []models.KVS) ([]models.KVS, error) {
+	return interpolate(basePath, pairs, func(fullKey string) (any, bool, error) {
+		resp, err := k.kvsClient.ValuesByKey(ctx, fullKey)
+		if err != nil {
+			if err.Code() == http.StatusNotFound {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		for _, kv := range resp.Response {
+			if kv.Key == fullKey {
+				return kv.Value, true, nil
+			}
+		}
+		return nil, false, nil
+	}, k.crypter.decrypt)
+}
+
 // ConfigurationValueExists checks if a configuration value exists in Core Keeper
-func (k *keeperClient) ConfigurationValueExists(name string) (bool, error) {
+func (k *keeperClient) ConfigurationValueExists(name string) (exists bool, err error) {
+	ctx, end := k.tracer.start(context.Background(), "ConfigurationValueExists", k.fullPath(name))
+	defer func() { end(err) }()
+	return k.configurationValueExists(ctx, name)
+}
+
+func (k *keeperClient) configurationValueExists(ctx context.Context, name string) (bool, error) {
 	keyPath := k.fullPath(name)
-	_, err := k.kvsClient.ListKeys(context.Background(), keyPath)
+	_, err := k.kvsClient.ListKeys(ctx, keyPath)
 	if err != nil {
 		if err.Code() == http.StatusNotFound {
 			return false, nil
 		}
-		return false, fmt.Errorf("checking configuration existence from Core Keeper failed: %v", err)
+		return false, newOperationError(keyPath, err, "checking configuration existence from Core Keeper failed")
 	}
 	return true, nil
 }
 
 // GetConfigurationValue gets a specific configuration value from Core Keeper
-func (k *keeperClient) GetConfigurationValue(name string) ([]byte, error) {
+func (k *keeperClient) GetConfigurationValue(name string) (_ []byte, err error) {
 	keyPath := k.fullPath(name)
-	return k.GetConfigurationValueByFullPath(keyPath)
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationValue", keyPath)
+	defer func() { end(err) }()
+	return k.getConfigurationValueByFullPath(ctx, keyPath)
 }
 
 // GetConfigurationValueByFullPath gets a specific configuration value given the full path from Core Keeper
-func (k *keeperClient) GetConfigurationValueByFullPath(fullPath string) ([]byte, error) {
-	resp, err := k.kvsClient.ValuesByKey(context.Background(), fullPath)
+func (k *keeperClient) GetConfigurationValueByFullPath(fullPath string) (_ []byte, err error) {
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationValueByFullPath", fullPath)
+	defer func() { end(err) }()
+	return k.getConfigurationValueByFullPath(ctx, fullPath)
+}
+
+func (k *keeperClient) getConfigurationValueByFullPath(ctx context.Context, fullPath string) ([]byte, error) {
+	resp, err := k.kvsClient.ValuesByKey(ctx, fullPath)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get value for %s from Core Keeper: %v", fullPath, err)
+		return nil, newOperationError(fullPath, err, "unable to get value for %s from Core Keeper", fullPath)
 	}
 	if len(resp.Response) == 0 {
-		return nil, fmt.Errorf("%s configuration not found", fullPath)
+		return nil, &types.OperationError{
+			Kind:    types.ErrNotFound,
+			KeyPath: fullPath,
+			Message: fmt.Sprintf("%s configuration not found", fullPath),
+		}
 	}
 
-	valueStr := cast.ToString(resp.Response[0].Value)
+	value, decryptErr := k.crypter.decrypt(fullPath, resp.Response[0].Value)
+	if decryptErr != nil {
+		return nil, decryptErr
+	}
+	valueStr := cast.ToString(value)
 	return []byte(valueStr), nil
 }
 
 // PutConfigurationValue puts a specific configuration value into Core Keeper
-func (k *keeperClient) PutConfigurationValue(name string, value []byte) error {
+func (k *keeperClient) PutConfigurationValue(name string, value []byte) (err error) {
+	ctx, end := k.tracer.start(context.Background(), "PutConfigurationValue", k.fullPath(name))
+	defer func() { end(err) }()
+	return k.putConfigurationValue(ctx, name, value)
+}
+
+func (k *keeperClient) putConfigurationValue(ctx context.Context, name string, value []byte) error {
 	keyPath := k.fullPath(name)
+	storedValue := string(value)
+	if k.crypter.isSensitive(name) && !isEncrypted(storedValue) {
+		var err error
+		if storedValue, err = k.crypter.encrypt(storedValue); err != nil {
+			return fmt.Errorf("unable to encrypt value for %s: %v", keyPath, err)
+		}
+	}
+	k.logger.trace("putting configuration value", "key", keyPath, "value", k.logger.redact(keyPath, storedValue))
 	request := requests.UpdateKeysRequest{
-		Value: string(value),
+		Value: storedValue,
 	}
-	_, err := k.kvsClient.UpdateValuesByKey(context.Background(), keyPath, false, request)
+	oldValues := k.auditedValues(ctx, keyPath, false)
+	_, err := k.kvsClient.UpdateValuesByKey(ctx, keyPath, false, request)
 	if err != nil {
-		return fmt.Errorf("unable to put value for %s into Core Keeper: %v", keyPath, err)
+		return newOperationError(keyPath, err, "unable to put value for %s into Core Keeper", keyPath)
 	}
+	oldValue, existed := oldValues[keyPath]
+	k.audit.record(keyPath, oldValue, existed, storedValue)
 	return nil
 }
 
-// GetConfigurationKeys returns all keys under name
-func (k *keeperClient) GetConfigurationKeys(name string) ([]string, error) {
+// GetConfigurationEntry gets a specific configuration value from Core Keeper along with its revision metadata
+func (k *keeperClient) GetConfigurationEntry(name string) (_ types.ConfigurationEntry, err error) {
 	keyPath := k.fullPath(name)
-	resp, err := k.kvsClient.ListKeys(context.Background(), keyPath)
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationEntry", keyPath)
+	defer func() { end(err) }()
+	return k.getConfigurationEntry(ctx, keyPath)
+}
+
+func (k *keeperClient) getConfigurationEntry(ctx context.Context, keyPath string) (types.ConfigurationEntry, error) {
+	kv, found, err := k.storedPair(ctx, keyPath)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get list of keys for %s from Core Keeper: %v", keyPath, err)
+		return types.ConfigurationEntry{}, err
+	}
+	if !found {
+		return types.ConfigurationEntry{}, &types.OperationError{
+			Kind:    types.ErrNotFound,
+			KeyPath: keyPath,
+			Message: fmt.Sprintf("%s configuration not found", keyPath),
+		}
+	}
+	return k.toEntry(kv, true)
+}
+
+// storedPair gets the key-value pair stored at keyPath itself, as stored in Core Keeper.
+// Returns false if keyPath has no value.
+func (k *keeperClient) storedPair(ctx context.Context, keyPath string) (models.KVS, bool, error) {
+	resp, edgexErr := k.kvsClient.ValuesByKey(ctx, keyPath)
+	if edgexErr != nil {
+		if edgexErr.Code() == http.StatusNotFound {
+			return models.KVS{}, false, nil
+		}
+		return models.KVS{}, false, newOperationError(keyPath, edgexErr, "unable to get value for %s from Core Keeper", keyPath)
+	}
+	// Core Keeper has no read of a single key, the values under keyPath are returned too
+	for _, kv := range resp.Response {
+		if kv.Key == keyPath {
+			return kv, true, nil
+		}
+	}
+	return models.KVS{}, false, nil
+}
+
+// toEntry converts a key-value pair retrieved from Core Keeper to a ConfigurationEntry, whose value is decrypted if
+// decrypt is true
+func (k *keeperClient) toEntry(kv models.KVS, decrypt bool) (types.ConfigurationEntry, error) {
+	value := kv.Value
+	if decrypt {
+		var err error
+		if value, err = k.crypter.decrypt(kv.Key, kv.Value); err != nil {
+			return types.ConfigurationEntry{}, err
+		}
+	}
+	return types.ConfigurationEntry{
+		Key:       kv.Key,
+		Value:     []byte(cast.ToString(value)),
+		Encrypted: isEncrypted(kv.Value),
+		Created:   toTime(kv.Created),
+		Modified:  toTime(kv.Modified),
+	}, nil
+}
+
+// GetConfigurationEntries gets the configuration values under name, and the value of name if any, from Core Keeper
+// along with their metadata, sorted by key
+func (k *keeperClient) GetConfigurationEntries(name string) (_ []types.ConfigurationEntry, err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationEntries", keyPath)
+	defer func() { end(err) }()
+	return k.getConfigurationEntries(ctx, keyPath, time.Time{}, true)
+}
+
+// GetStoredConfigurationEntries gets the configuration values under name, and the value of name if any, from Core
+// Keeper as stored, with the encrypted values left encrypted, along with their metadata, sorted by key
+func (k *keeperClient) GetStoredConfigurationEntries(name string) (_ []types.ConfigurationEntry, err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "GetStoredConfigurationEntries", keyPath)
+	defer func() { end(err) }()
+	return k.getConfigurationEntries(ctx, keyPath, time.Time{}, false)
+}
+
+// GetConfigurationEntriesModifiedSince gets the configuration values under name, and the value of name if any, which
+// were modified at or after since, along with their metadata, sorted by key. The filtering is done by the client, as
+// Core Keeper doesn't support it.
+func (k *keeperClient) GetConfigurationEntriesModifiedSince(name string, since time.Time) (_ []types.ConfigurationEntry, err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationEntriesModifiedSince", keyPath)
+	defer func() { end(err) }()
+	// the modification times are in milliseconds
+	return k.getConfigurationEntries(ctx, keyPath, since.Truncate(time.Millisecond), true)
+}
+
+// getConfigurationEntries gets the entries of keyPath and of the keys under it modified at or after since, or whose
+// modification time isn't known. Their values are decrypted if decrypt is true.
+func (k *keeperClient) getConfigurationEntries(ctx context.Context, keyPath string, since time.Time, decrypt bool) ([]types.ConfigurationEntry, error) {
+	resp, edgexErr := k.kvsClient.ValuesByKey(ctx, keyPath)
+	if edgexErr != nil {
+		return nil, newOperationError(keyPath, edgexErr, "unable to get values for %s from Core Keeper", keyPath)
+	}
+
+	var entries []types.ConfigurationEntry
+	for _, kv := range resp.Response {
+		// the keys sharing the prefix of keyPath, i.e. Writablex for Writable, aren't under it
+		if kv.Key != keyPath && !strings.HasPrefix(kv.Key, keyPath+KeyDelimiter) {
+			continue
+		}
+		entry, err := k.toEntry(kv, decrypt)
+		if err != nil {
+			return nil, err
+		}
+		if !entry.Modified.IsZero() && entry.Modified.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries, nil
+}
+
+// CheckAndPutConfigurationValue puts a specific configuration value into Core Keeper only if the current value meets
+// precondition. Core Keeper doesn't support conditional writes, so the current value is checked by a read preceding
+// the write: the check isn't atomic, and a concurrent write between them is overwritten.
+func (k *keeperClient) CheckAndPutConfigurationValue(name string, value []byte, precondition types.Precondition) (err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "CheckAndPutConfigurationValue", keyPath)
+	defer func() { end(err) }()
+
+	current, err := k.getConfigurationEntry(ctx, keyPath)
+	exists := err == nil
+	if err != nil && !errors.Is(err, types.ErrNotFound) {
+		return err
+	}
+
+	var conflict string
+	switch {
+	case precondition.Absent && exists:
+		conflict = "the key already exists"
+	case precondition.Absent:
+	case !exists:
+		conflict = "the key doesn't exist"
+	case precondition.Value != nil && !bytes.Equal(precondition.Value, current.Value):
+		conflict = "the current value differs from the expected value"
+	case precondition.Revision != 0 && precondition.Revision != current.Revision():
+		conflict = fmt.Sprintf("the current revision %d differs from the expected revision %d", current.Revision(), precondition.Revision)
+	}
+	if conflict != "" {
+		k.logger.debug("configuration value not written", "key", keyPath, "reason", conflict)
+		return &types.OperationError{
+			Kind:    types.ErrConflict,
+			KeyPath: keyPath,
+			Message: fmt.Sprintf("unable to put value for %s into Core Keeper: %s", keyPath, conflict),
+		}
+	}
+	return k.putConfigurationValue(ctx, name, value)
+}
+
+// GetConfigurationKeys returns all keys under name
+func (k *keeperClient) GetConfigurationKeys(name string) (_ []string, err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationKeys", keyPath)
+	defer func() { end(err) }()
+	return k.getConfigurationKeys(ctx, keyPath)
+}
+
+// GetConfigurationKeysByFullPath returns all keys under the full path
+func (k *keeperClient) GetConfigurationKeysByFullPath(fullPath string) (_ []string, err error) {
+	ctx, end := k.tracer.start(context.Background(), "GetConfigurationKeysByFullPath", fullPath)
+	defer func() { end(err) }()
+	return k.getConfigurationKeys(ctx, fullPath)
+}
+
+func (k *keeperClient) getConfigurationKeys(ctx context.Context, keyPath string) ([]string, error) {
+	resp, edgexErr := k.kvsClient.ListKeys(ctx, keyPath)
+	if edgexErr != nil {
+		return nil, newOperationError(keyPath, edgexErr, "unable to get list of keys for %s from Core Keeper", keyPath)
 	}
 
 	var list []string
@@ -336,3 +1023,91 @@ func (k *keeperClient) GetConfigurationKeys(name string) ([]string, error) {
 	}
 	return list, nil
 }
+
+// DeleteConfigurationValue deletes a specific configuration value from Core Keeper, along with all the values under it
+// if deep is true
+func (k *keeperClient) DeleteConfigurationValue(name string, deep bool) (err error) {
+	keyPath := k.fullPath(name)
+	ctx, end := k.tracer.start(context.Background(), "DeleteConfigurationValue", keyPath)
+	defer func() { end(err) }()
+
+	oldValues := k.auditedValues(ctx, keyPath, deep)
+	var edgexErr edgexErrors.EdgeX
+	if deep {
+		edgexErr = k.deleteSubtree(ctx, keyPath)
+	} else {
+		_, edgexErr = k.kvsClient.DeleteKey(ctx, keyPath)
+	}
+	if edgexErr != nil {
+		return newOperationError(keyPath, edgexErr, "unable to delete value for %s from Core Keeper", keyPath)
+	}
+	k.auditDeletion(oldValues)
+	return nil
+}
+
+// deleteSubtree deletes the value of keyPath along with the values under it. Core Keeper deletes all the keys starting
+// with the prefix, so the keys under keyPath are deleted by their own prefix, which the keys of the sibling segments
+// sharing keyPath as prefix don't have, i.e. "Writablex" for "Writable".
+// Returns a not found error only if neither keyPath nor any key under it exists.
+func (k *keeperClient) deleteSubtree(ctx context.Context, keyPath string) edgexErrors.EdgeX {
+	_, edgexErr := k.kvsClient.DeleteKeysByPrefix(ctx, keyPath+KeyDelimiter)
+	if edgexErr != nil && edgexErr.Code() != http.StatusNotFound {
+		return edgexErr
+	}
+	deletedUnder := edgexErr == nil
+	_, edgexErr = k.kvsClient.DeleteKey(ctx, keyPath)
+	if edgexErr != nil && deletedUnder && edgexErr.Code() == http.StatusNotFound {
+		return nil
+	}
+	return edgexErr
+}
+
+// RotateEncryptionKey re-encrypts the values stored under configBasePath which were encrypted with a previous key,
+// or which match the sensitive key patterns but are still stored as plaintext, with the current encryption key.
+func (k *keeperClient) RotateEncryptionKey() (err error) {
+	ctx, end := k.tracer.start(context.Background(), "RotateEncryptionKey", k.configBasePath)
+	defer func() { end(err) }()
+
+	if k.crypter == nil {
+		return errors.New("unable to rotate encryption key: no key provider is configured")
+	}
+	currentId, _, err := k.crypter.keyProvider.CurrentKey()
+	if err != nil {
+		return fmt.Errorf("failed to get the current encryption key: %v", err)
+	}
+
+	resp, edgexErr := k.kvsClient.ValuesByKey(ctx, k.configBasePath)
+	if edgexErr != nil {
+		if edgexErr.Code() == http.StatusNotFound {
+			return nil
+		}
+		return newOperationError(k.configBasePath, edgexErr, "unable to get configuration for %s from Core Keeper", k.configBasePath)
+	}
+
+	prefix := k.configBasePath + KeyDelimiter
+	for _, kv := range resp.Response {
+		if isEncrypted(kv.Value) {
+			if encryptedKeyId(kv.Value.(string)) == currentId {
+				continue
+			}
+		} else if !k.crypter.isSensitive(strings.TrimPrefix(kv.Key, prefix)) {
+			continue
+		}
+
+		plaintext, err := k.crypter.decrypt(kv.Key, kv.Value)
+		if err != nil {
+			return err
+		}
+		encrypted, err := k.crypter.encrypt(cast.ToString(plaintext))
+		if err != nil {
+			return fmt.Errorf("unable to re-encrypt value for %s: %v", kv.Key, err)
+		}
+		request := requests.UpdateKeysRequest{
+			Value: encrypted,
+		}
+		if _, edgexErr = k.kvsClient.UpdateValuesByKey(ctx, kv.Key, false, request); edgexErr != nil {
+			return newOperationError(kv.Key, edgexErr, "unable to put re-encrypted value for %s into Core Keeper", kv.Key)
+		}
+	}
+	return nil
+}
@@ -7,13 +7,23 @@ package keeper
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
 	"net/url"
 	"os"
+	"path"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	edgexErrors "github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/keepertest"
 	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
 
 	"github.com/stretchr/testify/assert"
@@ -52,7 +62,7 @@ func makeCoreKeeperClient(serviceName string) *keeperClient {
 		AuthInjector: NewNullAuthenticationInjector(),
 	}
 
-	client := NewKeeperClient(config)
+	client, _ := NewKeeperClient(config)
 	return client
 }
 
@@ -65,7 +75,7 @@ func configValueExists(key string, client *keeperClient) bool {
 	return exists
 }
 
-var mockCoreKeeper *MockCoreKeeper
+var mockCoreKeeper *keepertest.Server
 
 func reset(t *testing.T, client *keeperClient) {
 	// Make sure the configuration not exists
@@ -84,7 +94,7 @@ func reset(t *testing.T, client *keeperClient) {
 func TestMain(m *testing.M) {
 	var testMockServer *httptest.Server
 	if testHost == "" || port != 59883 {
-		mockCoreKeeper = NewMockCoreKeeper()
+		mockCoreKeeper = keepertest.NewServer()
 		testMockServer = mockCoreKeeper.Start()
 
 		URL, _ := url.Parse(testMockServer.URL)
@@ -285,6 +295,39 @@ func TestPutConfiguration(t *testing.T) {
 	assert.True(t, configValueExists("Temp", client))
 }
 
+func TestPutConfigurationWithoutOverwrite(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationValue("Host", []byte("edgex-core-data")))
+
+	// the struct is put key by key, only the keys which don't exist yet are created
+	config := TestConfig{
+		Logging: LoggingInfo{
+			EnableRemote: true,
+			File:         "NONE",
+		},
+		Port:     8000,
+		Host:     "localhost",
+		LogLevel: "debug",
+		Temp:     36.123456,
+	}
+	require.NoError(t, client.PutConfiguration(config, false))
+
+	value, err := client.GetConfigurationValue("Host")
+	require.NoError(t, err)
+	assert.Equal(t, "edgex-core-data", string(value))
+	value, err = client.GetConfigurationValue("Logging/File")
+	require.NoError(t, err)
+	assert.Equal(t, "NONE", string(value))
+	result, err := client.GetConfiguration(&TestConfig{})
+	require.NoError(t, err)
+	config.Host = "edgex-core-data"
+	assert.Equal(t, config, *result.(*TestConfig))
+}
+
 func TestGetConfiguration(t *testing.T) {
 	client := makeCoreKeeperClient(getUniqueServiceName())
 
@@ -356,6 +399,145 @@ func TestConfigurationValueExists(t *testing.T) {
 	}
 }
 
+func TestGetConfigurationEntry(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	_, err := client.GetConfigurationEntry("Writable")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+
+	before := time.Now().Truncate(time.Millisecond)
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("INFO")))
+	entry, err := client.GetConfigurationEntry("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, client.fullPath("Writable/LogLevel"), entry.Key)
+	assert.Equal(t, []byte("INFO"), entry.Value)
+	assert.False(t, entry.Created.Before(before))
+	assert.Equal(t, entry.Modified.UnixMilli(), entry.Revision())
+
+	// the key must be a value, not the parent of values
+	_, err = client.GetConfigurationEntry("Writable")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestGetConfigurationEntries(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	_, err := client.GetConfigurationEntries("Writable")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}, "Writablex": "other"}, true))
+	entries, err := client.GetConfigurationEntries("Writable")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, client.fullPath("Writable/LogLevel"), entries[0].Key)
+	assert.Equal(t, []byte("INFO"), entries[0].Value)
+	assert.Equal(t, client.fullPath("Writable/Port"), entries[1].Key)
+	assert.Equal(t, []byte("8080"), entries[1].Value)
+	assert.False(t, entries[1].Modified.IsZero())
+
+	entries, err = client.GetConfigurationEntries("")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestGetConfigurationEntriesModifiedSince(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+	time.Sleep(2 * time.Millisecond)
+	since := time.Now()
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+
+	entries, err := client.GetConfigurationEntriesModifiedSince("Writable", since)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, client.fullPath("Writable/LogLevel"), entries[0].Key)
+	assert.Equal(t, []byte("DEBUG"), entries[0].Value)
+
+	entries, err = client.GetConfigurationEntriesModifiedSince("Writable", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = client.GetConfigurationEntriesModifiedSince("", time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestCheckAndPutConfigurationValue(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	key := "Writable/LogLevel"
+	require.NoError(t, client.CheckAndPutConfigurationValue(key, []byte("INFO"), types.ExpectAbsent()))
+	err := client.CheckAndPutConfigurationValue(key, []byte("DEBUG"), types.ExpectAbsent())
+	assert.ErrorIs(t, err, types.ErrConflict)
+
+	err = client.CheckAndPutConfigurationValue(key, []byte("DEBUG"), types.ExpectValue([]byte("ERROR")))
+	assert.ErrorIs(t, err, types.ErrConflict)
+	require.NoError(t, client.CheckAndPutConfigurationValue(key, []byte("DEBUG"), types.ExpectValue([]byte("INFO"))))
+
+	entry, err := client.GetConfigurationEntry(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("DEBUG"), entry.Value)
+
+	// the write of another client changes the revision
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, client.PutConfigurationValue(key, []byte("ERROR")))
+	err = client.CheckAndPutConfigurationValue(key, []byte("TRACE"), types.ExpectRevision(entry.Revision()))
+	assert.ErrorIs(t, err, types.ErrConflict)
+	assert.Equal(t, types.ErrorKindConflict, types.ErrorKind(err))
+
+	entry, err = client.GetConfigurationEntry(key)
+	require.NoError(t, err)
+	require.NoError(t, client.CheckAndPutConfigurationValue(key, []byte("TRACE"), types.ExpectRevision(entry.Revision())))
+	value, err := client.GetConfigurationValue(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("TRACE"), value)
+
+	// a missing key doesn't meet any precondition but being absent
+	err = client.CheckAndPutConfigurationValue("Missing", []byte("value"), types.ExpectValue([]byte("")))
+	assert.ErrorIs(t, err, types.ErrConflict)
+}
+
+func TestDeleteConfigurationValue(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}, "Writablex": "other", "Foo": "bar"}, true))
+
+	require.NoError(t, client.DeleteConfigurationValue("Foo", false))
+	assert.False(t, configValueExists("Foo", client))
+	assert.True(t, configValueExists("Writable/LogLevel", client))
+
+	err := client.DeleteConfigurationValue("Writable", false)
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	// the keys sharing the prefix of the deleted subtree are kept
+	require.NoError(t, client.DeleteConfigurationValue("Writable", true))
+	keys, err := client.GetConfigurationKeys("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{client.fullPath("Writablex")}, keys)
+
+	err = client.DeleteConfigurationValue("Writable", true)
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	require.NoError(t, client.DeleteConfigurationValue("Writablex", true))
+	exists, err := client.HasConfiguration()
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestGetConfigurationValue(t *testing.T) {
 	client := makeCoreKeeperClient(getUniqueServiceName())
 
@@ -399,3 +581,547 @@ func TestPutConfigurationValue(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetConfigurationWithReferences(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	t.Setenv("KEEPER_TEST_LOG_LEVEL", "debug")
+	configMap := map[string]any{
+		"Host":     "localhost",
+		"Port":     8000,
+		"LogLevel": "${ENV:KEEPER_TEST_LOG_LEVEL}",
+		"Logging":  map[string]any{"EnableRemote": true, "File": "${Host}-${Port}.log"},
+	}
+	err := client.PutConfigurationMap(configMap, true)
+	require.NoError(t, err)
+
+	result, err := client.GetConfiguration(&TestConfig{})
+	require.NoError(t, err)
+	actual := result.(*TestConfig)
+	assert.Equal(t, "debug", actual.LogLevel)
+	assert.Equal(t, "localhost-8000.log", actual.Logging.File)
+
+	err = client.PutConfigurationValue("Host", []byte("${Logging/File}"))
+	require.NoError(t, err)
+	_, err = client.GetConfiguration(&TestConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cyclic configuration reference detected")
+}
+
+func TestGetConfigurationByFullPathWithReferences(t *testing.T) {
+	other := makeCoreKeeperClient(getUniqueServiceName())
+	defer reset(t, other)
+	client := makeCoreKeeperClient(getUniqueServiceName())
+	defer reset(t, client)
+
+	require.NoError(t, other.PutConfigurationMap(map[string]any{
+		"Host":    "other",
+		"Logging": map[string]any{"File": "${Host}.log"},
+	}, true))
+	require.NoError(t, client.PutConfigurationValue("Host", []byte("own")))
+
+	// the references are resolved against the base path of the other service
+	result, err := client.GetConfigurationByFullPath(&TestConfig{}, other.configBasePath)
+	require.NoError(t, err)
+	assert.Equal(t, "other.log", result.(*TestConfig).Logging.File)
+	logging, err := client.GetConfigurationByFullPath(&LoggingInfo{}, path.Join(other.configBasePath, "Logging"))
+	require.NoError(t, err)
+	assert.Equal(t, "other.log", logging.(*LoggingInfo).File)
+}
+
+func TestBasePathOf(t *testing.T) {
+	client := &keeperClient{configBasePath: "edgex/v4/core-data"}
+	tests := []struct {
+		fullPath string
+		expected string
+	}{
+		{"edgex/v4/core-data/Writable", "edgex/v4/core-data"},
+		{"edgex/v4/core-common-config-bootstrapper/Writable/LogLevel", "edgex/v4/core-common-config-bootstrapper"},
+		{"edgex/v4/core-common-config-bootstrapper", "edgex/v4/core-common-config-bootstrapper"},
+		{"edgex/v4", "edgex/v4"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, client.basePathOf(test.fullPath), test.fullPath)
+	}
+}
+
+func TestEncryptedConfiguration(t *testing.T) {
+	provider, err := types.NewStaticKeyProvider("key1", map[string][]byte{"key1": []byte("0123456789abcdef")})
+	require.NoError(t, err)
+
+	config := types.ServiceConfig{
+		Host:          testHost,
+		Port:          port,
+		BasePath:      getUniqueServiceName(),
+		AuthInjector:  NewNullAuthenticationInjector(),
+		KeyProvider:   provider,
+		SensitiveKeys: []string{"Logging/File"},
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	expected := TestConfig{
+		Logging: LoggingInfo{
+			EnableRemote: true,
+			File:         "secret.log",
+		},
+		Port: 8000,
+		Host: "localhost",
+	}
+	err = client.PutConfiguration(expected, true)
+	require.NoError(t, err)
+
+	// the sensitive value is encrypted at rest
+	resp, edgexErr := client.kvsClient.ValuesByKey(context.Background(), client.fullPath("Logging/File"))
+	require.NoError(t, edgexErr)
+	require.Len(t, resp.Response, 1)
+	assert.True(t, isEncrypted(resp.Response[0].Value))
+
+	result, err := client.GetConfiguration(&TestConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "secret.log", result.(*TestConfig).Logging.File)
+
+	value, err := client.GetConfigurationValue("Logging/File")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret.log"), value)
+
+	entries, err := client.GetConfigurationEntries("Logging")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, []byte("secret.log"), entries[1].Value)
+	assert.True(t, entries[1].Encrypted)
+	assert.False(t, entries[0].Encrypted)
+
+	// a client without the key provider can't decrypt the value
+	plainClient := makeCoreKeeperClient(config.BasePath)
+	_, err = plainClient.GetConfiguration(&TestConfig{})
+	assert.ErrorContains(t, err, "is encrypted but no key provider is configured")
+
+	// but reads it as stored
+	entries, err = plainClient.GetStoredConfigurationEntries("Logging")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, client.fullPath("Logging/File"), entries[1].Key)
+	assert.Equal(t, []byte(resp.Response[0].Value.(string)), entries[1].Value)
+	assert.True(t, entries[1].Encrypted)
+	assert.Equal(t, []byte("true"), entries[0].Value)
+}
+
+func TestEncryptedRawConfiguration(t *testing.T) {
+	provider, err := types.NewStaticKeyProvider("key1", map[string][]byte{"key1": []byte("0123456789abcdef")})
+	require.NoError(t, err)
+
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:          testHost,
+		Port:          port,
+		BasePath:      getUniqueServiceName(),
+		AuthInjector:  NewNullAuthenticationInjector(),
+		KeyProvider:   provider,
+		SensitiveKeys: []string{"Logging/File"},
+	})
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	// the sensitive values of the configuration passed as JSON are encrypted too
+	for _, config := range []any{
+		`{"Logging":{"File":"secret.log"},"Port":8000}`,
+		[]byte(`{"Logging":{"File":"secret.log"},"Port":8000}`),
+	} {
+		for _, overwrite := range []bool{true, false} {
+			require.NoError(t, client.PutConfiguration(config, overwrite))
+
+			resp, edgexErr := client.kvsClient.ValuesByKey(context.Background(), client.fullPath("Logging/File"))
+			require.NoError(t, edgexErr)
+			require.Len(t, resp.Response, 1)
+			assert.True(t, isEncrypted(resp.Response[0].Value), "%T overwrite %t", config, overwrite)
+
+			result, err := client.GetConfiguration(&TestConfig{})
+			require.NoError(t, err)
+			assert.Equal(t, "secret.log", result.(*TestConfig).Logging.File)
+			assert.Equal(t, 8000, result.(*TestConfig).Port)
+			reset(t, client)
+		}
+	}
+}
+
+func TestRotateEncryptionKey(t *testing.T) {
+	oldProvider, err := types.NewStaticKeyProvider("key1", map[string][]byte{"key1": []byte("0123456789abcdef")})
+	require.NoError(t, err)
+	basePath := getUniqueServiceName()
+	config := types.ServiceConfig{
+		Host:          testHost,
+		Port:          port,
+		BasePath:      basePath,
+		AuthInjector:  NewNullAuthenticationInjector(),
+		KeyProvider:   oldProvider,
+		SensitiveKeys: []string{"Password"},
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationValue("Password", []byte("secret")))
+	// stored as plaintext before the key was marked sensitive
+	plainClient := makeCoreKeeperClient(basePath)
+	require.NoError(t, plainClient.PutConfigurationValue("Token", []byte("token")))
+
+	newProvider, err := types.NewStaticKeyProvider("key2", map[string][]byte{
+		"key1": []byte("0123456789abcdef"),
+		"key2": []byte("abcdef0123456789"),
+	})
+	require.NoError(t, err)
+	config.KeyProvider = newProvider
+	config.SensitiveKeys = []string{"Password", "Token"}
+	client, err = NewKeeperClient(config)
+	require.NoError(t, err)
+	require.NoError(t, client.RotateEncryptionKey())
+
+	for key, expected := range map[string]string{"Password": "secret", "Token": "token"} {
+		resp, edgexErr := client.kvsClient.ValuesByKey(context.Background(), client.fullPath(key))
+		require.NoError(t, edgexErr)
+		require.Len(t, resp.Response, 1)
+		require.True(t, isEncrypted(resp.Response[0].Value))
+		assert.Equal(t, "key2", encryptedKeyId(resp.Response[0].Value.(string)))
+
+		value, err := client.GetConfigurationValue(key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(expected), value)
+	}
+
+	err = plainClient.RotateEncryptionKey()
+	assert.EqualError(t, err, "unable to rotate encryption key: no key provider is configured")
+}
+
+func TestGetConfigurationFromCache(t *testing.T) {
+	// proxy Core Keeper so that it can be taken offline during the test
+	keeperUrl, err := url.Parse(fmt.Sprintf("http://%s:%d", testHost, port))
+	require.NoError(t, err)
+	var offline atomic.Bool
+	proxy := httputil.NewSingleHostReverseProxy(keeperUrl)
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if offline.Load() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		proxy.ServeHTTP(writer, request)
+	}))
+	defer proxyServer.Close()
+	proxyUrl, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+	proxyPort, err := strconv.Atoi(proxyUrl.Port())
+	require.NoError(t, err)
+
+	config := types.ServiceConfig{
+		Host:         proxyUrl.Hostname(),
+		Port:         proxyPort,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Optional: map[string]any{
+			types.CacheDirOption:             t.TempDir(),
+			types.CacheRefreshIntervalOption: "10ms",
+		},
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	expected := TestConfig{
+		Logging: LoggingInfo{
+			EnableRemote: true,
+			File:         "NONE",
+		},
+		Port:     8000,
+		Host:     "localhost",
+		LogLevel: "debug",
+	}
+	require.NoError(t, client.PutConfiguration(expected, true))
+
+	_, err = client.GetConfiguration(&TestConfig{})
+	require.NoError(t, err)
+	status := client.CacheStatus()
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Stale)
+	assert.False(t, status.CachedAt.IsZero())
+
+	offline.Store(true)
+	result, err := client.GetConfiguration(&TestConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, *result.(*TestConfig))
+	status = client.CacheStatus()
+	assert.True(t, status.Stale)
+	assert.NotEmpty(t, status.LastError)
+
+	// the cache is refreshed once Core Keeper is reachable again
+	offline.Store(false)
+	assert.Eventually(t, func() bool {
+		return !client.CacheStatus().Stale
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCacheRefreshIntervalNotPositive(t *testing.T) {
+	_, err := NewKeeperClient(types.ServiceConfig{
+		Host:     testHost,
+		Port:     port,
+		BasePath: getUniqueServiceName(),
+		Optional: map[string]any{
+			types.CacheDirOption:             t.TempDir(),
+			types.CacheRefreshIntervalOption: "0s",
+		},
+	})
+	assert.EqualError(t, err, "invalid value for optional property CacheRefreshInterval: 0s is not positive")
+}
+
+func TestCloseStopsCacheRefresh(t *testing.T) {
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:     testHost,
+		Port:     port,
+		BasePath: getUniqueServiceName(),
+		Optional: map[string]any{
+			types.CacheDirOption:             t.TempDir(),
+			types.CacheRefreshIntervalOption: "5ms",
+		},
+	})
+	require.NoError(t, err)
+
+	// Core Keeper stays unreachable, so the refresh never succeeds
+	var attempts atomic.Int32
+	client.cache.startRefresh(func() bool {
+		attempts.Add(1)
+		return false
+	})
+	require.Eventually(t, func() bool { return attempts.Load() > 0 }, time.Second, time.Millisecond)
+
+	require.NoError(t, client.Close())
+	assert.Eventually(t, func() bool {
+		client.cache.mutex.Lock()
+		defer client.cache.mutex.Unlock()
+		return !client.cache.refreshing
+	}, time.Second, time.Millisecond)
+	stopped := attempts.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, attempts.Load())
+
+	// no refresh starts once closed
+	client.cache.startRefresh(func() bool {
+		attempts.Add(1)
+		return false
+	})
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, attempts.Load())
+
+	// closing a client without cache does nothing
+	assert.NoError(t, makeCoreKeeperClient(getUniqueServiceName()).Close())
+}
+
+func TestGetConfigurationWithoutCache(t *testing.T) {
+	config := types.ServiceConfig{
+		Host:         testHost,
+		Port:         1,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Optional:     map[string]any{types.CacheDirOption: t.TempDir()},
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+
+	// nothing was cached yet
+	_, err = client.GetConfiguration(&TestConfig{})
+	assert.ErrorContains(t, err, "the fallback to the local cache failed")
+	assert.False(t, client.CacheStatus().Stale)
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, nil)
+	policy := types.ReadinessPolicy{Interval: 5 * time.Millisecond}
+
+	// becomes ready once the ping succeeds
+	flakyKeeper.FailNext(2, http.StatusServiceUnavailable)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, client.WaitUntilReady(ctx, policy))
+	assert.Equal(t, 3, flakyKeeper.Requests())
+
+	// waits for the configuration to be seeded
+	policy.WaitForConfiguration = true
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = client.PutConfigurationValue("Foo", []byte("bar"))
+	}()
+	require.NoError(t, client.WaitUntilReady(ctx, policy))
+	exists, err := client.HasConfiguration()
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestWaitUntilReadyTimeout(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, nil)
+	policy := types.ReadinessPolicy{Interval: 5 * time.Millisecond, WaitForConfiguration: true}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := client.WaitUntilReady(ctx, policy)
+	var readinessErr *types.ReadinessError
+	require.ErrorAs(t, err, &readinessErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, readinessErr.Reachable)
+	assert.True(t, readinessErr.ConfigurationMissing)
+	assert.Greater(t, readinessErr.Attempts, 1)
+	assert.Contains(t, err.Error(), "doesn't exist yet")
+
+	flakyKeeper.FailNext(1000, http.StatusServiceUnavailable)
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err = client.WaitUntilReady(ctx, policy)
+	require.ErrorAs(t, err, &readinessErr)
+	assert.False(t, readinessErr.Reachable)
+	assert.NotEmpty(t, readinessErr.PingError)
+	assert.Contains(t, err.Error(), "unable to ping the Configuration service")
+}
+
+func TestWaitUntilReadyHangingRequest(t *testing.T) {
+	release := make(chan struct{})
+	hangingServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-release
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer hangingServer.Close()
+	// the hanging request is released before closing the server, which waits for it
+	defer close(release)
+	serverUrl, err := url.Parse(hangingServer.URL)
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+
+	// the ping never responds, WaitUntilReady still returns once ctx expires
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = client.WaitUntilReady(ctx, types.ReadinessPolicy{Interval: 5 * time.Millisecond})
+	assert.Less(t, time.Since(start), time.Second)
+	var readinessErr *types.ReadinessError
+	require.ErrorAs(t, err, &readinessErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, readinessErr.Attempts)
+	assert.False(t, readinessErr.Reachable)
+}
+
+func TestHealthCheck(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	status := client.HealthCheck()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, types.AuthStatusOK, status.Auth)
+	assert.NotEmpty(t, status.ApiVersion)
+	assert.Positive(t, status.Latency)
+	assert.Empty(t, status.Error)
+	assert.True(t, status.LastSuccessfulWrite.IsZero())
+	assert.False(t, status.LastSuccessfulRead.IsZero())
+
+	require.NoError(t, client.PutConfigurationValue("Foo", []byte("bar")))
+	status = client.HealthCheck()
+	assert.False(t, status.LastSuccessfulWrite.IsZero())
+}
+
+func TestHealthCheckFailures(t *testing.T) {
+	unauthorizedServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == common.ApiPingRoute {
+			writer.WriteHeader(http.StatusOK)
+			return
+		}
+		writer.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorizedServer.Close()
+	serverUrl, err := url.Parse(unauthorizedServer.URL)
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+	})
+	require.NoError(t, err)
+	status := client.HealthCheck()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, types.AuthStatusUnauthorized, status.Auth)
+
+	unauthorizedServer.Close()
+	status = client.HealthCheck()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, types.AuthStatusUnknown, status.Auth)
+	assert.Equal(t, http.StatusServiceUnavailable, status.StatusCode)
+	assert.NotEmpty(t, status.Error)
+	assert.NotEmpty(t, status.ErrorKind)
+}
+
+func TestHealthCheckWatchActivity(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// no message client means the watch fails straight away
+	errorChannel := make(chan error, 1)
+	client.WatchForChanges(make(chan interface{}), errorChannel, &TestConfig{}, "Writable", func() messaging.MessageClient { return nil })
+	require.Error(t, <-errorChannel)
+
+	status := client.HealthCheck()
+	assert.Zero(t, status.ActiveWatches)
+	assert.Equal(t, "unable to use MessageClient to watch for configuration changes", status.LastWatchError)
+	assert.False(t, status.LastWatchErrorTime.IsZero())
+}
+
+func TestErrorTaxonomy(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, nil)
+
+	_, err := client.GetConfiguration(&TestConfig{})
+	assert.ErrorIs(t, err, types.ErrNotFound)
+
+	_, err = client.GetConfigurationValue("Missing")
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	var operationErr *types.OperationError
+	require.ErrorAs(t, err, &operationErr)
+	assert.Equal(t, client.fullPath("Missing"), operationErr.KeyPath)
+	assert.Equal(t, http.StatusNotFound, operationErr.Code)
+	var edgexErr edgexErrors.EdgeX
+	require.ErrorAs(t, err, &edgexErr)
+	assert.Equal(t, http.StatusNotFound, edgexErr.Code())
+
+	flakyKeeper.FailNext(1, http.StatusUnauthorized)
+	err = client.PutConfigurationValue("Port", []byte("abc"))
+	assert.ErrorIs(t, err, types.ErrUnauthorized)
+	assert.NotErrorIs(t, err, types.ErrNotFound)
+
+	flakyKeeper.FailNext(1, http.StatusServiceUnavailable)
+	_, err = client.GetConfigurationKeys("")
+	assert.ErrorIs(t, err, types.ErrUnavailable)
+
+	require.NoError(t, client.PutConfigurationValue("Port", []byte("abc")))
+	_, err = client.GetConfiguration(&TestConfig{})
+	assert.ErrorIs(t, err, types.ErrDecode)
+	var decodeErr *types.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, client.configBasePath, decodeErr.KeyPath)
+}
@@ -6,7 +6,9 @@
 package keeper
 
 import (
+	"encoding/json"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cast"
 )
@@ -41,3 +43,28 @@ func convertInterfaceToPairs(path string, interfaceMap any) []*pair {
 
 	return pairs
 }
+
+// toTime converts a timestamp of Core Keeper, in milliseconds since the epoch, to a time, which is zero if not set
+func toTime(milliseconds int64) time.Time {
+	if milliseconds == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(milliseconds)
+}
+
+// toConfigurationMap converts a configuration struct to a map, by a round trip through JSON so that the keys match the
+// ones Core Keeper flattens the struct into
+func toConfigurationMap(config any) (map[string]any, error) {
+	if configMap, ok := config.(map[string]any); ok {
+		return configMap, nil
+	}
+	bytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var configMap map[string]any
+	if err = json.Unmarshal(bytes, &configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
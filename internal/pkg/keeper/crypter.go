@@ -0,0 +1,239 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// encryptedValuePrefix marks a stored value as encrypted, it is followed by "<key id>:<base64 of nonce and ciphertext>"
+	encryptedValuePrefix = "enc:v1:"
+	sensitiveTagName     = "config"
+	sensitiveTagValue    = "sensitive"
+)
+
+// valueCrypter encrypts and decrypts the sensitive configuration values with AES-GCM
+type valueCrypter struct {
+	keyProvider types.KeyProvider
+	patterns    []string
+}
+
+func newValueCrypter(keyProvider types.KeyProvider, patterns []string) *valueCrypter {
+	if keyProvider == nil {
+		return nil
+	}
+	return &valueCrypter{
+		keyProvider: keyProvider,
+		patterns:    patterns,
+	}
+}
+
+// isSensitive checks if the key relative to the configBasePath matches one of the sensitive key patterns
+func (c *valueCrypter) isSensitive(key string) bool {
+	if c == nil {
+		return false
+	}
+	return matchesAny(c.patterns, key)
+}
+
+// matchesAny checks if key matches one of the path.Match patterns
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isEncrypted(value any) bool {
+	str, ok := value.(string)
+	return ok && strings.HasPrefix(str, encryptedValuePrefix)
+}
+
+// encryptedKeyId returns the id of the key which encrypted value
+func encryptedKeyId(value string) string {
+	id, _, _ := strings.Cut(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	return id
+}
+
+// encrypt encrypts the plaintext with the current key of the key provider
+func (c *valueCrypter) encrypt(plaintext string) (string, error) {
+	id, key, err := c.keyProvider.CurrentKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the current encryption key: %v", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedValuePrefix + id + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt returns the plaintext of value if it is encrypted, otherwise value is returned as is.
+// key is the full path of the value and is only used for error messages.
+func (c *valueCrypter) decrypt(key string, value any) (any, error) {
+	if !isEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return nil, fmt.Errorf("value of key %s is encrypted but no key provider is configured", key)
+	}
+
+	id, encoded, found := strings.Cut(strings.TrimPrefix(value.(string), encryptedValuePrefix), ":")
+	if !found {
+		return nil, fmt.Errorf("malformed encrypted value of key %s", key)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value of key %s: %v", key, err)
+	}
+	encryptionKey, err := c.keyProvider.Key(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the encryption key for key %s: %v", key, err)
+	}
+	gcm, err := newGCM(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("malformed encrypted value of key %s", key)
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the value of key %s: %v", key, err)
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES-GCM cipher: %v", err)
+	}
+	return gcm, nil
+}
+
+// encryptConfiguration converts the configuration struct or map to a map with the sensitive values encrypted.
+// A configuration passed as JSON, in a string or a []byte, is converted too.
+func (c *valueCrypter) encryptConfiguration(config any) (any, error) {
+	if raw, ok := config.([]byte); ok {
+		config = string(raw)
+	}
+	if raw, ok := config.(string); ok {
+		var configMap map[string]any
+		if json.Unmarshal([]byte(raw), &configMap) != nil {
+			// Core Keeper stores the values which aren't JSON objects as is at the base path
+			return c.encryptValues("", raw, nil)
+		}
+		config = configMap
+	}
+
+	sensitivePaths := make(map[string]bool)
+	collectSensitivePaths(reflect.TypeOf(config), "", sensitivePaths)
+
+	configMap, err := toConfigurationMap(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the configuration for encryption: %v", err)
+	}
+
+	return c.encryptValues("", configMap, sensitivePaths)
+}
+
+func (c *valueCrypter) encryptValues(keyPath string, value any, sensitivePaths map[string]bool) (any, error) {
+	pathPre := ""
+	if keyPath != "" {
+		pathPre = keyPath + KeyDelimiter
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for name, item := range v {
+			encrypted, err := c.encryptValues(pathPre+name, item, sensitivePaths)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = encrypted
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for index, item := range v {
+			encrypted, err := c.encryptValues(fmt.Sprintf("%s%d", pathPre, index), item, sensitivePaths)
+			if err != nil {
+				return nil, err
+			}
+			result[index] = encrypted
+		}
+		return result, nil
+	default:
+		if value == nil || isEncrypted(value) || (!sensitivePaths[keyPath] && !c.isSensitive(keyPath)) {
+			return value, nil
+		}
+		return c.encrypt(cast.ToString(value))
+	}
+}
+
+// collectSensitivePaths collects the key paths of the struct fields tagged with `config:"sensitive"`
+func collectSensitivePaths(t reflect.Type, keyPath string, paths map[string]bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return
+	}
+
+	pathPre := ""
+	if keyPath != "" {
+		pathPre = keyPath + KeyDelimiter
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case jsonName == "-":
+			continue
+		case jsonName != "":
+			name = jsonName
+		case field.Anonymous:
+			// the fields of an embedded struct are promoted to the parent level by the JSON encoding
+			collectSensitivePaths(field.Type, keyPath, paths)
+			continue
+		}
+		if field.Tag.Get(sensitiveTagName) == sensitiveTagValue {
+			paths[pathPre+name] = true
+		}
+		collectSensitivePaths(field.Type, pathPre+name, paths)
+	}
+}
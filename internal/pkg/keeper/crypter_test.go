@@ -0,0 +1,130 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey1 = []byte("0123456789abcdef0123456789abcdef")
+	testKey2 = []byte("fedcba9876543210")
+)
+
+func makeTestCrypter(t *testing.T, currentId string, patterns ...string) *valueCrypter {
+	provider, err := types.NewStaticKeyProvider(currentId, map[string][]byte{"key1": testKey1, "key2": testKey2})
+	require.NoError(t, err)
+	return newValueCrypter(provider, patterns)
+}
+
+func TestValueCrypterRoundTrip(t *testing.T) {
+	crypter := makeTestCrypter(t, "key1")
+
+	encrypted, err := crypter.encrypt("secret")
+	require.NoError(t, err)
+	assert.True(t, isEncrypted(encrypted))
+	assert.Equal(t, "key1", encryptedKeyId(encrypted))
+	assert.NotContains(t, encrypted, "secret")
+
+	decrypted, err := crypter.decrypt("svc/Password", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	// values encrypted with a previous key are still readable after rotation
+	rotated := makeTestCrypter(t, "key2")
+	decrypted, err = rotated.decrypt("svc/Password", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	// plaintext values are returned as is
+	decrypted, err = crypter.decrypt("svc/Port", float64(8080))
+	require.NoError(t, err)
+	assert.Equal(t, float64(8080), decrypted)
+}
+
+func TestValueCrypterDecryptErrors(t *testing.T) {
+	crypter := makeTestCrypter(t, "key1")
+	encrypted, err := crypter.encrypt("secret")
+	require.NoError(t, err)
+
+	var noCrypter *valueCrypter
+	_, err = noCrypter.decrypt("svc/Password", encrypted)
+	assert.EqualError(t, err, "value of key svc/Password is encrypted but no key provider is configured")
+
+	_, err = crypter.decrypt("svc/Password", encryptedValuePrefix+"unknown:AAAA")
+	assert.ErrorContains(t, err, "failed to get the encryption key for key svc/Password")
+
+	_, err = crypter.decrypt("svc/Password", encryptedValuePrefix+"key1:not-base64")
+	assert.ErrorContains(t, err, "malformed encrypted value of key svc/Password")
+
+	tampered := encrypted[:len(encrypted)-4] + "AAAA"
+	_, err = crypter.decrypt("svc/Password", tampered)
+	assert.ErrorContains(t, err, "failed to decrypt the value of key svc/Password")
+}
+
+func TestIsSensitive(t *testing.T) {
+	crypter := makeTestCrypter(t, "key1", "Database/Password", "Clients/*/Token")
+
+	assert.True(t, crypter.isSensitive("Database/Password"))
+	assert.True(t, crypter.isSensitive("Clients/core-data/Token"))
+	assert.False(t, crypter.isSensitive("Database/Host"))
+	assert.False(t, crypter.isSensitive("Clients/core-data/Host"))
+
+	var noCrypter *valueCrypter
+	assert.False(t, noCrypter.isSensitive("Database/Password"))
+}
+
+type SecretInfo struct {
+	Username string
+	Password string `config:"sensitive"`
+}
+
+type Embedded struct {
+	ApiKey string `config:"sensitive"`
+}
+
+type SensitiveConfig struct {
+	Embedded
+	Database SecretInfo
+	Token    string `json:"token" config:"sensitive"`
+	Ignored  string `json:"-" config:"sensitive"`
+	Host     string
+}
+
+func TestCollectSensitivePaths(t *testing.T) {
+	paths := make(map[string]bool)
+	collectSensitivePaths(reflect.TypeOf(&SensitiveConfig{}), "", paths)
+
+	assert.Equal(t, map[string]bool{"ApiKey": true, "Database/Password": true, "token": true}, paths)
+}
+
+func TestEncryptConfiguration(t *testing.T) {
+	crypter := makeTestCrypter(t, "key1", "Host")
+
+	config := SensitiveConfig{
+		Embedded: Embedded{ApiKey: "key"},
+		Database: SecretInfo{Username: "admin", Password: "password"},
+		Token:    "token",
+		Host:     "localhost",
+	}
+	result, err := crypter.encryptConfiguration(config)
+	require.NoError(t, err)
+
+	configMap, ok := result.(map[string]any)
+	require.True(t, ok)
+	assert.True(t, isEncrypted(configMap["ApiKey"]))
+	assert.True(t, isEncrypted(configMap["token"]))
+	assert.True(t, isEncrypted(configMap["Host"]))
+	database := configMap["Database"].(map[string]any)
+	assert.True(t, isEncrypted(database["Password"]))
+	assert.Equal(t, "admin", database["Username"])
+}
@@ -12,11 +12,14 @@ import (
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
 	"github.com/mitchellh/mapstructure"
 )
 
-// decode converts the key-value pairs from core keeper to the target configuration data type
-func decode(prefix string, pairs []models.KVS, configTarget interface{}) error {
+// decode converts the key-value pairs from core keeper to the target configuration data type.
+// The encrypted values are decrypted with crypter, which is nil if encryption is disabled.
+func decode(prefix string, pairs []models.KVS, configTarget interface{}, crypter *valueCrypter) error {
 	// check if the prefix ends with the '/' char
 	if !strings.HasSuffix(prefix, KeyDelimiter) {
 		prefix += KeyDelimiter
@@ -41,13 +44,16 @@ func decode(prefix string, pairs []models.KVS, configTarget interface{}) error {
 
 				subm, ok := m[child].(map[string]interface{})
 				if !ok {
-					return fmt.Errorf("child is both a data item and dir: %s", child)
+					return &types.DecodeError{KeyPath: p.Key, Err: fmt.Errorf("child is both a data item and dir: %s", child)}
 				}
 
 				m = subm
 			}
 		}
-		value := p.Value
+		value, err := crypter.decrypt(p.Key, p.Value)
+		if err != nil {
+			return &types.DecodeError{KeyPath: p.Key, Err: err}
+		}
 		switch value.(type) {
 		case bool:
 			m[key] = value
@@ -68,7 +74,7 @@ func decode(prefix string, pairs []models.KVS, configTarget interface{}) error {
 		case string:
 			m[key] = value
 		default:
-			return errors.New("unknown data type of the stored value")
+			return &types.DecodeError{KeyPath: p.Key, Err: errors.New("unknown data type of the stored value")}
 		}
 	}
 
@@ -79,10 +85,10 @@ func decode(prefix string, pairs []models.KVS, configTarget interface{}) error {
 		Result:           configTarget,
 	})
 	if err != nil {
-		return fmt.Errorf("json decoding failed, err: %v", err)
+		return &types.DecodeError{KeyPath: strings.TrimSuffix(prefix, KeyDelimiter), Err: fmt.Errorf("json decoding failed, err: %v", err)}
 	}
 	if err := decoder.Decode(raw); err != nil {
-		return fmt.Errorf("json decoding failed, err: %v", err)
+		return &types.DecodeError{KeyPath: strings.TrimSuffix(prefix, KeyDelimiter), Err: fmt.Errorf("json decoding failed, err: %v", err)}
 	}
 
 	return nil
@@ -0,0 +1,192 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// deliveryPolicy defines how the updates and the errors of a watch are sent to its consumer
+type deliveryPolicy struct {
+	policy types.WatchDeliveryPolicy
+	buffer int
+}
+
+func newDeliveryPolicy(config types.ServiceConfig) (deliveryPolicy, error) {
+	var policy deliveryPolicy
+	name, err := config.GetOptionalString(types.WatchDeliveryPolicyOption, string(types.WatchDeliveryBlock))
+	if err != nil {
+		return policy, err
+	}
+	policy.policy = types.WatchDeliveryPolicy(name)
+	switch policy.policy {
+	case types.WatchDeliveryBlock, types.WatchDeliveryDropOldest, types.WatchDeliveryKeepLatest:
+	default:
+		return policy, fmt.Errorf("invalid value for optional property %s: unknown policy %s", types.WatchDeliveryPolicyOption, name)
+	}
+	if policy.buffer, err = config.GetOptionalInt(types.WatchDeliveryBufferOption, types.DefaultWatchDeliveryBuffer); err != nil {
+		return policy, err
+	}
+	if policy.buffer < 1 {
+		return policy, fmt.Errorf("invalid value for optional property %s: %d is not positive", types.WatchDeliveryBufferOption, policy.buffer)
+	}
+	return policy, nil
+}
+
+// watchDelivery sends the updates and the errors of a watch to its channels according to the delivery policy.
+// With the Block policy the sends are made by the watch itself, and are abandoned once StopWatching is called.
+// With the other policies they're queued for a forwarding goroutine, which stops along with the watch.
+type watchDelivery struct {
+	deliveryPolicy
+	updateChannel chan<- interface{}
+	errorChannel  chan<- error
+	stop          <-chan bool
+	stopped       chan struct{}
+	logger        *clientLogger
+
+	mutex   sync.Mutex
+	updates []interface{}
+	errors  []error
+	// ready signals the forwarding goroutine that an update or an error was queued
+	ready chan struct{}
+}
+
+func newWatchDelivery(policy deliveryPolicy, updateChannel chan<- interface{}, errorChannel chan<- error, stop <-chan bool, logger *clientLogger) *watchDelivery {
+	d := &watchDelivery{
+		deliveryPolicy: policy,
+		updateChannel:  updateChannel,
+		errorChannel:   errorChannel,
+		stop:           stop,
+		stopped:        make(chan struct{}),
+		logger:         logger,
+		ready:          make(chan struct{}, 1),
+	}
+	if d.policy != types.WatchDeliveryBlock {
+		go d.forward()
+	}
+	return d
+}
+
+// sendFirstUpdate sends the nil update notifying the watch is established, which is never dropped.
+// Returns false if StopWatching was called meanwhile.
+func (d *watchDelivery) sendFirstUpdate() bool {
+	select {
+	case d.updateChannel <- nil:
+		return true
+	case <-d.stop:
+		return false
+	}
+}
+
+// sendUpdate sends the updated configuration to the consumer.
+// Returns false if StopWatching was called while waiting for the consumer.
+func (d *watchDelivery) sendUpdate(update interface{}) bool {
+	if d.policy == types.WatchDeliveryBlock {
+		select {
+		case d.updateChannel <- update:
+			return true
+		case <-d.stop:
+			return false
+		}
+	}
+	d.mutex.Lock()
+	var dropped bool
+	d.updates, dropped = enqueue(d.deliveryPolicy, d.updates, update)
+	d.mutex.Unlock()
+	if dropped {
+		d.logger.debug("dropped a configuration update not received yet", "policy", d.policy)
+	}
+	d.signal()
+	return true
+}
+
+// sendError sends the watch error to the consumer.
+// Returns false if StopWatching was called while waiting for the consumer.
+func (d *watchDelivery) sendError(err error) bool {
+	if d.policy == types.WatchDeliveryBlock {
+		select {
+		case d.errorChannel <- err:
+			return true
+		case <-d.stop:
+			return false
+		}
+	}
+	d.mutex.Lock()
+	var dropped bool
+	d.errors, dropped = enqueue(d.deliveryPolicy, d.errors, err)
+	d.mutex.Unlock()
+	if dropped {
+		d.logger.debug("dropped a watch error not received yet", "policy", d.policy, "error", err)
+	}
+	d.signal()
+	return true
+}
+
+// close stops the forwarding goroutine, the updates and the errors not sent yet are discarded
+func (d *watchDelivery) close() {
+	close(d.stopped)
+}
+
+func (d *watchDelivery) signal() {
+	select {
+	case d.ready <- struct{}{}:
+	default:
+	}
+}
+
+// enqueue appends item to queue, dropping the items the policy doesn't keep. Returns true if any was dropped.
+func enqueue[T any](policy deliveryPolicy, queue []T, item T) ([]T, bool) {
+	switch {
+	case policy.policy == types.WatchDeliveryKeepLatest:
+		return append(queue[:0], item), len(queue) > 0
+	case len(queue) >= policy.buffer:
+		return append(queue[1:], item), true
+	default:
+		return append(queue, item), false
+	}
+}
+
+// forward sends the queued updates and errors to the consumer until the watch stops. With the KeepLatest policy,
+// the update or the error waiting for the consumer is replaced once a newer one is queued.
+func (d *watchDelivery) forward() {
+	var update interface{}
+	var err error
+	var hasUpdate bool
+	for {
+		d.mutex.Lock()
+		if len(d.updates) > 0 && (!hasUpdate || d.policy == types.WatchDeliveryKeepLatest) {
+			update, hasUpdate = d.updates[0], true
+			d.updates = d.updates[1:]
+		}
+		if len(d.errors) > 0 && (err == nil || d.policy == types.WatchDeliveryKeepLatest) {
+			err = d.errors[0]
+			d.errors = d.errors[1:]
+		}
+		d.mutex.Unlock()
+
+		// a nil channel disables its case while there's nothing to send on it
+		var updateChannel chan<- interface{}
+		if hasUpdate {
+			updateChannel = d.updateChannel
+		}
+		var errorChannel chan<- error
+		if err != nil {
+			errorChannel = d.errorChannel
+		}
+		select {
+		case <-d.stopped:
+			return
+		case <-d.ready:
+		case updateChannel <- update:
+			update, hasUpdate = nil, false
+		case errorChannel <- err:
+			err = nil
+		}
+	}
+}
@@ -0,0 +1,247 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeliveryPolicy(t *testing.T) {
+	policy, err := newDeliveryPolicy(types.ServiceConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, deliveryPolicy{policy: types.WatchDeliveryBlock, buffer: types.DefaultWatchDeliveryBuffer}, policy)
+
+	policy, err = newDeliveryPolicy(types.ServiceConfig{Optional: map[string]any{
+		types.WatchDeliveryPolicyOption: "DropOldest",
+		types.WatchDeliveryBufferOption: "4",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, deliveryPolicy{policy: types.WatchDeliveryDropOldest, buffer: 4}, policy)
+
+	_, err = newDeliveryPolicy(types.ServiceConfig{Optional: map[string]any{types.WatchDeliveryPolicyOption: "Latest"}})
+	assert.ErrorContains(t, err, "unknown policy Latest")
+	_, err = newDeliveryPolicy(types.ServiceConfig{Optional: map[string]any{types.WatchDeliveryBufferOption: 0}})
+	assert.ErrorContains(t, err, "0 is not positive")
+}
+
+// queuedErrors returns the number of errors queued but not taken by the forwarding goroutine yet
+func (d *watchDelivery) queuedErrors() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.errors)
+}
+
+func TestWatchDeliveryDropOldest(t *testing.T) {
+	errorChannel := make(chan error)
+	delivery := newWatchDelivery(deliveryPolicy{policy: types.WatchDeliveryDropOldest, buffer: 2}, make(chan interface{}), errorChannel, make(chan bool), nil)
+	defer delivery.close()
+
+	first := errors.New("error 1")
+	require.True(t, delivery.sendError(first))
+	// wait for the first error to be sent, so that it isn't part of the buffer anymore
+	require.Eventually(t, func() bool { return delivery.queuedErrors() == 0 }, time.Second, time.Millisecond)
+	for i := 2; i <= 4; i++ {
+		require.True(t, delivery.sendError(fmt.Errorf("error %d", i)))
+	}
+
+	assert.Equal(t, first, receiveError(t, errorChannel))
+	assert.EqualError(t, receiveError(t, errorChannel), "error 3")
+	assert.EqualError(t, receiveError(t, errorChannel), "error 4")
+	assert.Empty(t, errorChannel)
+}
+
+func TestWatchDeliveryKeepLatest(t *testing.T) {
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	delivery := newWatchDelivery(deliveryPolicy{policy: types.WatchDeliveryKeepLatest, buffer: 1}, updateChannel, errorChannel, make(chan bool), nil)
+	defer delivery.close()
+
+	for i := 1; i <= 4; i++ {
+		require.True(t, delivery.sendError(fmt.Errorf("error %d", i)))
+		require.True(t, delivery.sendUpdate(i))
+	}
+	// wait for the latest error to replace the one waiting for the consumer
+	require.Eventually(t, func() bool { return delivery.queuedErrors() == 0 }, time.Second, time.Millisecond)
+
+	assert.EqualError(t, receiveError(t, errorChannel), "error 4")
+	assert.Equal(t, 4, receiveUpdate(t, updateChannel))
+	select {
+	case update := <-updateChannel:
+		assert.Fail(t, "unexpected update", "%v", update)
+	case err := <-errorChannel:
+		assert.Fail(t, "unexpected error", "%v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWatchDeliveryConcurrent(t *testing.T) {
+	for _, policy := range []types.WatchDeliveryPolicy{types.WatchDeliveryBlock, types.WatchDeliveryDropOldest, types.WatchDeliveryKeepLatest} {
+		t.Run(string(policy), func(t *testing.T) {
+			updateChannel := make(chan interface{})
+			errorChannel := make(chan error)
+			stop := make(chan bool, 1)
+			delivery := newWatchDelivery(deliveryPolicy{policy: policy, buffer: 4}, updateChannel, errorChannel, stop, nil)
+
+			// the consumer reads some updates and errors, then stops reading
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 50 {
+					select {
+					case <-updateChannel:
+					case <-errorChannel:
+					}
+				}
+			}()
+
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				defer delivery.close()
+				for i := 0; ; i++ {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					if !delivery.sendUpdate(i) || !delivery.sendError(fmt.Errorf("error %d", i)) {
+						return
+					}
+				}
+			}()
+
+			wg.Wait()
+			stop <- true
+			select {
+			case <-stopped:
+			case <-time.After(time.Second):
+				require.Fail(t, "the delivery didn't stop")
+			}
+		})
+	}
+}
+
+func TestStopWatchingWithBusyConsumer(t *testing.T) {
+	for _, policy := range []types.WatchDeliveryPolicy{types.WatchDeliveryBlock, types.WatchDeliveryDropOldest, types.WatchDeliveryKeepLatest} {
+		t.Run(string(policy), func(t *testing.T) {
+			client, err := NewKeeperClient(types.ServiceConfig{
+				Host:         testHost,
+				Port:         port,
+				BasePath:     getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				Optional:     map[string]any{types.WatchDeliveryPolicyOption: string(policy)},
+			})
+			require.NoError(t, err)
+
+			// delete the configuration created
+			defer reset(t, client)
+
+			require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}}, true))
+
+			messageClient := &fakeMessageClient{}
+			updateChannel := make(chan interface{})
+			errorChannel := make(chan error)
+			client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+			assert.Nil(t, receiveUpdate(t, updateChannel))
+
+			// the consumer doesn't receive the update, which blocks the watch with the Block policy
+			logLevelKey := client.fullPath("Writable/LogLevel")
+			require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+			messageClient.publishChange(t, logLevelKey, "DEBUG")
+			if policy != types.WatchDeliveryBlock {
+				// the other policies keep processing the changes and the errors meanwhile
+				messageClient.errors <- errors.New("message bus failure")
+				messageClient.publishChange(t, logLevelKey, "DEBUG")
+				messageClient.errors <- errors.New("message bus failure")
+			}
+
+			client.StopWatching()
+			assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+			assert.Zero(t, client.HealthCheck().ActiveWatches)
+		})
+	}
+}
+
+func TestWatchDeliveryQueuedUpdates(t *testing.T) {
+	tests := []struct {
+		policy   types.WatchDeliveryPolicy
+		expected []string
+	}{
+		{types.WatchDeliveryDropOldest, []string{"DEBUG", "ERROR", "WARN", "TRACE"}},
+		// the latest update queued may replace the one waiting for the consumer while it's received
+		{types.WatchDeliveryKeepLatest, []string{"WARN", "TRACE"}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			metrics := newRecordingMetrics()
+			client, err := NewKeeperClient(types.ServiceConfig{
+				Host:         testHost,
+				Port:         port,
+				BasePath:     getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				Metrics:      metrics,
+				Optional:     map[string]any{types.WatchDeliveryPolicyOption: string(test.policy)},
+			})
+			require.NoError(t, err)
+
+			// delete the configuration created
+			defer reset(t, client)
+
+			require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}}, true))
+
+			messageClient := &fakeMessageClient{}
+			updateChannel := make(chan interface{})
+			client.WatchForChanges(updateChannel, make(chan error, 10), &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+			defer func() {
+				client.StopWatching()
+				assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+			}()
+			assert.Nil(t, receiveUpdate(t, updateChannel))
+
+			// the updates are queued while the consumer doesn't receive them
+			logLevelKey := client.fullPath("Writable/LogLevel")
+			for i, logLevel := range []string{"DEBUG", "ERROR", "WARN", "TRACE"} {
+				require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte(logLevel)))
+				messageClient.publishChange(t, logLevelKey, logLevel)
+				if logLevel != "TRACE" {
+					require.Eventually(t, func() bool { return metrics.watchCount(types.WatchMessageDecoded) == i+1 }, time.Second, 10*time.Millisecond)
+				}
+			}
+
+			// each queued update keeps its own configuration, which isn't modified by the change processed meanwhile
+			var received []string
+			for len(received) == 0 || received[len(received)-1] != "TRACE" {
+				received = append(received, receiveUpdate(t, updateChannel).(*WritableInfo).LogLevel)
+			}
+			if test.policy == types.WatchDeliveryKeepLatest {
+				assert.Subset(t, test.expected, received)
+				return
+			}
+			assert.Equal(t, test.expected, received)
+		})
+	}
+}
+
+func TestStopWatchingBeforeFirstUpdate(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	messageClient := &fakeMessageClient{}
+	client.WatchForChanges(make(chan interface{}), make(chan error), &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+	client.StopWatching()
+	assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+}
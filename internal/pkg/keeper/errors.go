@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// errorKind maps the status code of a failed Core Keeper request to the error category
+func errorKind(code int) error {
+	switch code {
+	case http.StatusNotFound:
+		return types.ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return types.ErrUnauthorized
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return types.ErrConflict
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return types.ErrUnavailable
+	default:
+		return nil
+	}
+}
+
+// newOperationError wraps the error of a failed Core Keeper request for keyPath, preserving its status code
+func newOperationError(keyPath string, err errors.EdgeX, format string, args ...any) error {
+	return &types.OperationError{
+		Kind:    errorKind(err.Code()),
+		KeyPath: keyPath,
+		Code:    err.Code(),
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
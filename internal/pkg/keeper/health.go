@@ -0,0 +1,168 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// activityTracker records the client activity reported by HealthCheck
+type activityTracker struct {
+	mutex              sync.Mutex
+	lastRead           time.Time
+	lastWrite          time.Time
+	activeWatches      int
+	lastWatchError     string
+	lastWatchErrorTime time.Time
+}
+
+func (a *activityTracker) recordRead() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.lastRead = time.Now()
+}
+
+func (a *activityTracker) recordWrite() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.lastWrite = time.Now()
+}
+
+func (a *activityTracker) watchStarted() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.activeWatches++
+}
+
+func (a *activityTracker) watchStopped() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.activeWatches--
+}
+
+func (a *activityTracker) recordWatchError(err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.lastWatchError = err.Error()
+	a.lastWatchErrorTime = time.Now()
+}
+
+// fill copies the recorded activity into status
+func (a *activityTracker) fill(status *types.HealthStatus) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	status.LastSuccessfulRead = a.lastRead
+	status.LastSuccessfulWrite = a.lastWrite
+	status.ActiveWatches = a.activeWatches
+	status.LastWatchError = a.lastWatchError
+	status.LastWatchErrorTime = a.lastWatchErrorTime
+}
+
+// succeeded checks if Core Keeper handled the request, a missing key is a successful answer
+func succeeded(err errors.EdgeX) bool {
+	return err == nil || err.Code() == http.StatusNotFound
+}
+
+// trackingKVSClient decorates a KVSClient to record the successful reads and writes
+type trackingKVSClient struct {
+	activity *activityTracker
+	client   interfaces.KVSClient
+}
+
+func (t *trackingKVSClient) UpdateValuesByKey(ctx context.Context, key string, flatten bool, reqs requests.UpdateKeysRequest) (responses.KeysResponse, errors.EdgeX) {
+	res, err := t.client.UpdateValuesByKey(ctx, key, flatten, reqs)
+	if err == nil {
+		t.activity.recordWrite()
+	}
+	return res, err
+}
+
+func (t *trackingKVSClient) ValuesByKey(ctx context.Context, key string) (responses.MultiKeyValueResponse, errors.EdgeX) {
+	res, err := t.client.ValuesByKey(ctx, key)
+	if succeeded(err) {
+		t.activity.recordRead()
+	}
+	return res, err
+}
+
+func (t *trackingKVSClient) ListKeys(ctx context.Context, key string) (responses.KeysResponse, errors.EdgeX) {
+	res, err := t.client.ListKeys(ctx, key)
+	if succeeded(err) {
+		t.activity.recordRead()
+	}
+	return res, err
+}
+
+func (t *trackingKVSClient) DeleteKey(ctx context.Context, key string) (responses.KeysResponse, errors.EdgeX) {
+	res, err := t.client.DeleteKey(ctx, key)
+	if err == nil {
+		t.activity.recordWrite()
+	}
+	return res, err
+}
+
+func (t *trackingKVSClient) DeleteKeysByPrefix(ctx context.Context, key string) (responses.KeysResponse, errors.EdgeX) {
+	res, err := t.client.DeleteKeysByPrefix(ctx, key)
+	if err == nil {
+		t.activity.recordWrite()
+	}
+	return res, err
+}
+
+// HealthCheck pings Core Keeper and checks the client's credentials with an authenticated request for the
+// service's configuration, and reports them together with the recorded client activity
+func (k *keeperClient) HealthCheck() (status types.HealthStatus) {
+	ctx, end := k.tracer.start(context.Background(), "HealthCheck", k.configBasePath)
+	defer func() { end(status.Err()) }()
+
+	status = types.HealthStatus{
+		Url:  k.keeperUrl,
+		Auth: types.AuthStatusUnknown,
+	}
+	k.activity.fill(&status)
+
+	start := time.Now()
+	resp, err := k.commonClient.Ping(ctx)
+	status.Latency = time.Since(start)
+	if err != nil {
+		status.Error = err.Error()
+		status.ErrorKind = err.Kind()
+		status.StatusCode = err.Code()
+		return status
+	}
+	status.ServiceName = resp.ServiceName
+	status.ApiVersion = resp.ApiVersion
+
+	// the ping API doesn't require authentication, so check the credentials against the configuration
+	_, err = k.kvsClient.ListKeys(ctx, k.configBasePath)
+	switch {
+	case succeeded(err):
+		status.Auth = types.AuthStatusOK
+	case err.Code() == http.StatusUnauthorized:
+		status.Auth = types.AuthStatusUnauthorized
+	case err.Code() == http.StatusForbidden:
+		status.Auth = types.AuthStatusForbidden
+	default:
+		status.Error = err.Error()
+		status.ErrorKind = err.Kind()
+		status.StatusCode = err.Code()
+	}
+	status.Healthy = status.Auth == types.AuthStatusOK
+
+	// refresh the activity as the authenticated request above is also a read
+	k.activity.fill(&status)
+	return status
+}
@@ -0,0 +1,168 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	interpolationStart = "${"
+	interpolationEnd   = "}"
+	envReferencePrefix = "ENV:"
+)
+
+// valueDecrypter returns the plaintext of the stored value of key if it is encrypted
+type valueDecrypter func(key string, value any) (any, error)
+
+// valueLookup returns the stored value of the key with the given full path and whether the key exists.
+// It is used to resolve references to keys which are not part of the key-value pairs being interpolated.
+type valueLookup func(fullKey string) (any, bool, error)
+
+// interpolator resolves the `${Other/Key/Path}` and `${ENV:VAR}` references found in the stored values.
+// Key references are relative to basePath, i.e. the base path of the service whose configuration is interpolated.
+type interpolator struct {
+	basePath string
+	values   map[string]any
+	resolved map[string]string
+	lookup   valueLookup
+	decrypt  valueDecrypter
+}
+
+// interpolate returns a copy of pairs with all the references in the string values resolved.
+// An error naming the offending keys is returned if a reference can't be resolved or the references form a cycle.
+// Referenced values are decrypted with decrypt, if set, before being substituted.
+func interpolate(basePath string, pairs []models.KVS, lookup valueLookup, decrypt valueDecrypter) ([]models.KVS, error) {
+	ip := &interpolator{
+		basePath: basePath,
+		values:   make(map[string]any, len(pairs)),
+		resolved: make(map[string]string),
+		lookup:   lookup,
+		decrypt:  decrypt,
+	}
+	for _, p := range pairs {
+		ip.values[p.Key] = p.Value
+	}
+
+	result := make([]models.KVS, len(pairs))
+	for i, p := range pairs {
+		result[i] = p
+		str, ok := p.Value.(string)
+		if !ok || !strings.Contains(str, interpolationStart) {
+			continue
+		}
+		value, err := ip.resolve(p.Key, nil)
+		if err != nil {
+			return nil, err
+		}
+		result[i].Value = value
+	}
+
+	return result, nil
+}
+
+// resolve returns the interpolated value of key, where visiting holds the chain of keys being resolved
+func (ip *interpolator) resolve(key string, visiting []string) (string, error) {
+	if value, ok := ip.resolved[key]; ok {
+		return value, nil
+	}
+	for i, k := range visiting {
+		if k == key {
+			cycle := append(append([]string{}, visiting[i:]...), key)
+			return "", fmt.Errorf("cyclic configuration reference detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	raw, err := ip.rawValue(key, visiting)
+	if err != nil {
+		return "", err
+	}
+	if ip.decrypt != nil {
+		if raw, err = ip.decrypt(key, raw); err != nil {
+			return "", err
+		}
+	}
+
+	visiting = append(visiting, key)
+	str := cast.ToString(raw)
+	var builder strings.Builder
+	for {
+		start := strings.Index(str, interpolationStart)
+		if start < 0 {
+			builder.WriteString(str)
+			break
+		}
+		if start > 0 && str[start-1] == '$' {
+			// `$${` is an escaped `${`, so write it out literally
+			builder.WriteString(str[:start-1] + interpolationStart)
+			str = str[start+len(interpolationStart):]
+			continue
+		}
+		end := strings.Index(str[start:], interpolationEnd)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated reference '%s' in the value of key %s", str[start:], key)
+		}
+		end += start
+
+		reference := str[start+len(interpolationStart) : end]
+		value, err := ip.resolveReference(key, reference, visiting)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(str[:start] + value)
+		str = str[end+len(interpolationEnd):]
+	}
+
+	ip.resolved[key] = builder.String()
+	return ip.resolved[key], nil
+}
+
+// resolveReference returns the value of a single reference found in the value of key
+func (ip *interpolator) resolveReference(key string, reference string, visiting []string) (string, error) {
+	if envName, ok := strings.CutPrefix(reference, envReferencePrefix); ok {
+		value, found := os.LookupEnv(envName)
+		if !found {
+			return "", fmt.Errorf("environment variable %s referenced by key %s is not set", envName, key)
+		}
+		return value, nil
+	}
+
+	if reference == "" {
+		return "", fmt.Errorf("empty reference in the value of key %s", key)
+	}
+	return ip.resolve(path.Join(ip.basePath, reference), visiting)
+}
+
+// rawValue returns the stored value of key, looking it up from Core Keeper if it is not one of the pairs being interpolated
+func (ip *interpolator) rawValue(key string, visiting []string) (any, error) {
+	if value, ok := ip.values[key]; ok {
+		return value, nil
+	}
+
+	referrer := "unknown"
+	if len(visiting) > 0 {
+		referrer = visiting[len(visiting)-1]
+	}
+	if ip.lookup == nil {
+		return nil, fmt.Errorf("key %s referenced by key %s not found", key, referrer)
+	}
+	value, found, err := ip.lookup(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up key %s referenced by key %s: %v", key, referrer, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("key %s referenced by key %s not found", key, referrer)
+	}
+	ip.values[key] = value
+	return value, nil
+}
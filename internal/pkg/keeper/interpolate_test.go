@@ -0,0 +1,144 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeKVS(key string, value any) models.KVS {
+	return models.KVS{Key: key, StoredData: models.StoredData{Value: value}}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("INTERPOLATE_TEST_HOST", "edgex-redis")
+
+	outsideLookup := func(fullKey string) (any, bool, error) {
+		if fullKey == "svc/Shared/Port" {
+			return float64(6379), true, nil
+		}
+		return nil, false, nil
+	}
+
+	testCases := []struct {
+		Name     string
+		Pairs    []models.KVS
+		Lookup   valueLookup
+		Expected map[string]any
+		ErrorMsg string
+	}{
+		{
+			Name: "No references",
+			Pairs: []models.KVS{
+				makeKVS("svc/Host", "localhost"),
+				makeKVS("svc/Port", float64(59880)),
+			},
+			Expected: map[string]any{"svc/Host": "localhost", "svc/Port": float64(59880)},
+		},
+		{
+			Name: "Key reference",
+			Pairs: []models.KVS{
+				makeKVS("svc/Database/Host", "localhost"),
+				makeKVS("svc/MessageBus/Host", "${Database/Host}"),
+				makeKVS("svc/Url", "http://${Database/Host}:${Database/Port}"),
+				makeKVS("svc/Database/Port", float64(6379)),
+			},
+			Expected: map[string]any{
+				"svc/Database/Host":   "localhost",
+				"svc/MessageBus/Host": "localhost",
+				"svc/Url":             "http://localhost:6379",
+				"svc/Database/Port":   float64(6379),
+			},
+		},
+		{
+			Name: "Chained key reference",
+			Pairs: []models.KVS{
+				makeKVS("svc/A", "${B}"),
+				makeKVS("svc/B", "${C}"),
+				makeKVS("svc/C", "value"),
+			},
+			Expected: map[string]any{"svc/A": "value", "svc/B": "value", "svc/C": "value"},
+		},
+		{
+			Name:     "Environment reference",
+			Pairs:    []models.KVS{makeKVS("svc/Host", "${ENV:INTERPOLATE_TEST_HOST}")},
+			Expected: map[string]any{"svc/Host": "edgex-redis"},
+		},
+		{
+			Name:     "Escaped reference",
+			Pairs:    []models.KVS{makeKVS("svc/Template", "$${Host} is ${Host}"), makeKVS("svc/Host", "localhost")},
+			Expected: map[string]any{"svc/Template": "${Host} is localhost", "svc/Host": "localhost"},
+		},
+		{
+			Name:     "Reference looked up outside the pairs",
+			Pairs:    []models.KVS{makeKVS("svc/Writable/Port", "${Shared/Port}")},
+			Lookup:   outsideLookup,
+			Expected: map[string]any{"svc/Writable/Port": "6379"},
+		},
+		{
+			Name:     "Missing key",
+			Pairs:    []models.KVS{makeKVS("svc/Host", "${Missing}")},
+			Lookup:   outsideLookup,
+			ErrorMsg: "key svc/Missing referenced by key svc/Host not found",
+		},
+		{
+			Name: "Lookup error",
+			Pairs: []models.KVS{
+				makeKVS("svc/Host", "${Missing}"),
+			},
+			Lookup: func(string) (any, bool, error) {
+				return nil, false, errors.New("keeper down")
+			},
+			ErrorMsg: "failed to look up key svc/Missing referenced by key svc/Host: keeper down",
+		},
+		{
+			Name:     "Missing environment variable",
+			Pairs:    []models.KVS{makeKVS("svc/Host", "${ENV:INTERPOLATE_TEST_MISSING}")},
+			ErrorMsg: "environment variable INTERPOLATE_TEST_MISSING referenced by key svc/Host is not set",
+		},
+		{
+			Name: "Cyclic reference",
+			Pairs: []models.KVS{
+				makeKVS("svc/A", "${B}"),
+				makeKVS("svc/B", "${C}"),
+				makeKVS("svc/C", "${A}"),
+			},
+			ErrorMsg: "cyclic configuration reference detected: svc/A -> svc/B -> svc/C -> svc/A",
+		},
+		{
+			Name:     "Self reference",
+			Pairs:    []models.KVS{makeKVS("svc/A", "x${A}")},
+			ErrorMsg: "cyclic configuration reference detected: svc/A -> svc/A",
+		},
+		{
+			Name:     "Unterminated reference",
+			Pairs:    []models.KVS{makeKVS("svc/A", "${B")},
+			ErrorMsg: "unterminated reference '${B' in the value of key svc/A",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			actual, err := interpolate("svc", testCase.Pairs, testCase.Lookup, nil)
+			if testCase.ErrorMsg != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.ErrorMsg, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, actual, len(testCase.Expected))
+			for _, kv := range actual {
+				assert.Equal(t, testCase.Expected[kv.Key], kv.Value, "value of %s not as expected", kv.Key)
+			}
+		})
+	}
+}
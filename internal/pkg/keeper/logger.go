@@ -0,0 +1,153 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+const (
+	redactedValue = "<redacted>"
+	// slogLevelTrace is the level of the trace logs emitted with a *slog.Logger
+	slogLevelTrace = slog.LevelDebug - 4
+)
+
+// traceLogger is implemented by the loggers with a trace level, i.e. the core-contracts LoggingClient
+type traceLogger interface {
+	Trace(msg string, args ...any)
+}
+
+// clientLogger emits the debug, trace and warning logs of the client, with the sensitive values redacted.
+// A nil clientLogger, which is used if no logger is configured, discards the logs.
+type clientLogger struct {
+	logger   types.Logger
+	basePath string
+	patterns []string
+}
+
+func newClientLogger(logger types.Logger, basePath string, patterns []string) *clientLogger {
+	if logger == nil {
+		return nil
+	}
+	return &clientLogger{
+		logger:   logger,
+		basePath: basePath,
+		patterns: patterns,
+	}
+}
+
+func (l *clientLogger) debug(msg string, args ...any) {
+	if l != nil {
+		l.logger.Debug(msg, args...)
+	}
+}
+
+func (l *clientLogger) warn(msg string, args ...any) {
+	if l != nil {
+		l.logger.Warn(msg, args...)
+	}
+}
+
+func (l *clientLogger) trace(msg string, args ...any) {
+	if l == nil {
+		return
+	}
+	switch logger := l.logger.(type) {
+	case traceLogger:
+		logger.Trace(msg, args...)
+	case *slog.Logger:
+		logger.Log(context.Background(), slogLevelTrace, msg, args...)
+	default:
+		logger.Debug(msg, args...)
+	}
+}
+
+// isSensitive checks if the full key path matches one of the sensitive key patterns
+func (l *clientLogger) isSensitive(keyPath string) bool {
+	return matchesAny(l.patterns, strings.TrimPrefix(keyPath, l.basePath+KeyDelimiter))
+}
+
+// redact returns the value of the key at keyPath to log, which hides the sensitive and the encrypted values
+func (l *clientLogger) redact(keyPath string, value any) any {
+	if l == nil || isEncrypted(value) || l.isSensitive(keyPath) {
+		return redactedValue
+	}
+	return value
+}
+
+// redactError returns the error to log, which hides the decode errors of sensitive keys as they may quote the value
+func (l *clientLogger) redactError(err error) any {
+	var decodeErr *types.DecodeError
+	if l == nil || (errors.As(err, &decodeErr) && l.isSensitive(decodeErr.KeyPath)) {
+		return redactedValue
+	}
+	return err
+}
+
+// loggingAuthInjector decorates an AuthenticationInjector to log the requests sent to Core Keeper.
+// Only the method and the path of the requests are logged, the values in the request and response bodies aren't.
+type loggingAuthInjector struct {
+	injector interfaces.AuthenticationInjector
+	logger   *clientLogger
+}
+
+func (l *loggingAuthInjector) AddAuthenticationData(req *http.Request) error {
+	if l.injector == nil {
+		return nil
+	}
+	return l.injector.AddAuthenticationData(req)
+}
+
+func (l *loggingAuthInjector) RoundTripper() http.RoundTripper {
+	var next http.RoundTripper
+	if l.injector != nil {
+		next = l.injector.RoundTripper()
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &loggingRoundTripper{next: next, logger: l.logger}
+}
+
+type loggingRoundTripper struct {
+	next   http.RoundTripper
+	logger *clientLogger
+}
+
+func (l *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := l.next.RoundTrip(req)
+	args := []any{
+		"method", req.Method,
+		"path", req.URL.Path,
+		"correlationId", req.Header.Get(common.CorrelationHeader),
+		"duration", time.Since(start),
+	}
+	if err != nil {
+		l.logger.debug("request to Core Keeper failed", append(args, "error", err)...)
+		return resp, err
+	}
+	l.logger.trace("request to Core Keeper", append(args, "status", resp.StatusCode)...)
+	return resp, nil
+}
+
+// withLogging returns the AuthenticationInjector to create the http clients with
+func withLogging(injector interfaces.AuthenticationInjector, logger *clientLogger) interfaces.AuthenticationInjector {
+	if logger == nil {
+		return injector
+	}
+	return &loggingAuthInjector{injector: injector, logger: logger}
+}
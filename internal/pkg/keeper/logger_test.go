@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// both the core-contracts LoggingClient and slog are supported
+var (
+	_ types.Logger = logger.NewMockClient()
+	_ types.Logger = slog.Default()
+)
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writes of the watch goroutine
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+// traceRecorder is a logger with a trace level, like the core-contracts LoggingClient
+type traceRecorder struct {
+	mutex  sync.Mutex
+	traces []string
+	debugs []string
+}
+
+func (r *traceRecorder) Trace(msg string, _ ...any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.traces = append(r.traces, msg)
+}
+
+func (r *traceRecorder) Debug(msg string, _ ...any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.debugs = append(r.debugs, msg)
+}
+
+func (r *traceRecorder) Info(string, ...any)  {}
+func (r *traceRecorder) Warn(string, ...any)  {}
+func (r *traceRecorder) Error(string, ...any) {}
+
+func makeLoggingKeeperClient(t *testing.T, logger types.Logger) *keeperClient {
+	config := types.ServiceConfig{
+		Host:          testHost,
+		Port:          port,
+		BasePath:      getUniqueServiceName(),
+		AuthInjector:  NewNullAuthenticationInjector(),
+		SensitiveKeys: []string{"Writable/Password"},
+		Logger:        logger,
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+	return client
+}
+
+func TestLogging(t *testing.T) {
+	output := &syncBuffer{}
+	client := makeLoggingKeeperClient(t, slog.New(slog.NewTextHandler(output, &slog.HandlerOptions{Level: slogLevelTrace})))
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Password": "secret"}}, true))
+	logs := output.String()
+	assert.Contains(t, logs, `msg="request to Core Keeper" method=PUT`)
+	assert.Contains(t, logs, "status=200")
+	assert.Contains(t, logs, "value=INFO")
+	assert.Contains(t, logs, "value="+redactedValue)
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &struct{ LogLevel, Password string }{}, "Writable", func() messaging.MessageClient { return messageClient })
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+
+	// the outdated change of a sensitive value is skipped without logging the values
+	messageClient.publishChange(t, client.fullPath("Writable/Password"), "hunter2")
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClient.publishChange(t, client.fullPath("Writable/LogLevel"), "DEBUG")
+	receiveUpdate(t, updateChannel)
+
+	client.StopWatching()
+	assert.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "stopped watching for configuration changes")
+	}, time.Second, 10*time.Millisecond)
+
+	logs = output.String()
+	assert.Contains(t, logs, `msg="watching for configuration changes"`)
+	assert.Contains(t, logs, `msg="skipping outdated configuration change"`)
+	assert.Contains(t, logs, `msg="received configuration change"`)
+	assert.NotContains(t, logs, "secret")
+	assert.NotContains(t, logs, "hunter2")
+}
+
+func TestLoggingTraceLevel(t *testing.T) {
+	logger := &traceRecorder{}
+	client := makeLoggingKeeperClient(t, logger)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	_, err := client.GetConfigurationValue("Missing")
+	require.Error(t, err)
+	assert.Equal(t, []string{"request to Core Keeper"}, logger.traces)
+	assert.Empty(t, logger.debugs)
+}
+
+func TestRedact(t *testing.T) {
+	logger := newClientLogger(&traceRecorder{}, "edgex/core-data", []string{"Database/Password", "Clients/*/Token"})
+
+	assert.Equal(t, "INFO", logger.redact("edgex/core-data/Writable/LogLevel", "INFO"))
+	assert.Equal(t, redactedValue, logger.redact("edgex/core-data/Database/Password", "secret"))
+	assert.Equal(t, redactedValue, logger.redact("edgex/core-data/Clients/core-command/Token", "token"))
+	assert.Equal(t, redactedValue, logger.redact("edgex/core-data/Writable/LogLevel", encryptedValuePrefix+"id:abc"))
+
+	failure := errors.New("parsing \"secret\" failed")
+	assert.Equal(t, failure, logger.redactError(failure))
+	assert.Equal(t, redactedValue, logger.redactError(&types.DecodeError{KeyPath: "edgex/core-data/Database/Password", Err: failure}))
+
+	var disabled *clientLogger
+	assert.Equal(t, redactedValue, disabled.redact("edgex/core-data/Writable/LogLevel", "INFO"))
+	disabled.debug("discarded")
+	disabled.trace("discarded")
+}
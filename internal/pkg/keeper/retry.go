@@ -0,0 +1,252 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// retryPolicy defines how the failed requests to Core Keeper are retried
+type retryPolicy struct {
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         float64
+	statusCodes    []int
+}
+
+func newRetryPolicy(config types.ServiceConfig) (retryPolicy, error) {
+	var policy retryPolicy
+	var err error
+	if policy.attempts, err = config.GetOptionalInt(types.RetryAttemptsOption, 1); err != nil {
+		return policy, err
+	}
+	if policy.initialBackoff, err = config.GetOptionalDuration(types.RetryInitialBackoffOption, types.DefaultRetryInitialBackoff); err != nil {
+		return policy, err
+	}
+	if policy.maxBackoff, err = config.GetOptionalDuration(types.RetryMaxBackoffOption, types.DefaultRetryMaxBackoff); err != nil {
+		return policy, err
+	}
+	if policy.jitter, err = config.GetOptionalFloat(types.RetryJitterOption, types.DefaultRetryJitter); err != nil {
+		return policy, err
+	}
+	if policy.statusCodes, err = config.GetOptionalIntSlice(types.RetryStatusCodesOption, types.DefaultRetryStatusCodes); err != nil {
+		return policy, err
+	}
+	policy.jitter = min(max(policy.jitter, 0), 1)
+	return policy, nil
+}
+
+func (p retryPolicy) isRetryable(err errors.EdgeX) bool {
+	return slices.Contains(p.statusCodes, err.Code())
+}
+
+// backoff returns the wait before the given retry, starting from 1
+func (p retryPolicy) backoff(retry int) time.Duration {
+	wait := p.initialBackoff
+	for i := 1; i < retry && wait < p.maxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.maxBackoff)
+	if p.jitter > 0 {
+		// randomize the wait within [wait * (1 - jitter), wait]
+		// nolint:gosec
+		wait -= time.Duration(rand.Float64() * p.jitter * float64(wait))
+	}
+	return wait
+}
+
+// circuitBreaker stops sending requests to Core Keeper after too many consecutive failures, and lets a single trial
+// request through once openTimeout has elapsed to find out if Core Keeper has recovered
+type circuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+
+	mutex     sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func newCircuitBreaker(config types.ServiceConfig) (*circuitBreaker, error) {
+	threshold, err := config.GetOptionalInt(types.CircuitBreakerThresholdOption, 0)
+	if err != nil {
+		return nil, err
+	}
+	openTimeout, err := config.GetOptionalDuration(types.CircuitBreakerOpenTimeoutOption, types.DefaultCircuitBreakerOpenTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if threshold <= 0 {
+		return nil, nil
+	}
+	return &circuitBreaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+	}, nil
+}
+
+// allow checks if a request may be sent
+func (cb *circuitBreaker) allow() errors.EdgeX {
+	if cb == nil {
+		return nil
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.failures < cb.threshold {
+		return nil
+	}
+	if time.Now().Before(cb.openUntil) || cb.trial {
+		return errors.NewCommonEdgeX(errors.KindServiceUnavailable, "circuit breaker is open, the request to Core Keeper is not sent", nil)
+	}
+	// half-open: only one trial request is let through until its result is known
+	cb.trial = true
+	return nil
+}
+
+// record updates the circuit breaker with the result of a request
+func (cb *circuitBreaker) record(failed bool) {
+	if cb == nil {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.trial = false
+	if !failed {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openUntil = time.Now().Add(cb.openTimeout)
+	}
+}
+
+// resilientCaller sends the requests to Core Keeper through the circuit breaker and retries the transient failures
+type resilientCaller struct {
+	policy  retryPolicy
+	breaker *circuitBreaker
+}
+
+func (rc *resilientCaller) call(ctx context.Context, request func() errors.EdgeX) errors.EdgeX {
+	var err errors.EdgeX
+	for attempt := 1; ; attempt++ {
+		if err = rc.breaker.allow(); err != nil {
+			return err
+		}
+		err = request()
+		// only the transient failures count against the circuit breaker, i.e. a 404 means Core Keeper is up
+		failed := err != nil && rc.policy.isRetryable(err)
+		rc.breaker.record(failed)
+		if !failed || attempt >= rc.policy.attempts {
+			return err
+		}
+
+		timer := time.NewTimer(rc.policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// resilientKVSClient decorates a KVSClient with the retry policy and the circuit breaker
+type resilientKVSClient struct {
+	resilientCaller
+	client interfaces.KVSClient
+}
+
+func (r *resilientKVSClient) UpdateValuesByKey(ctx context.Context, key string, flatten bool, reqs requests.UpdateKeysRequest) (res responses.KeysResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.UpdateValuesByKey(ctx, key, flatten, reqs)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientKVSClient) ValuesByKey(ctx context.Context, key string) (res responses.MultiKeyValueResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.ValuesByKey(ctx, key)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientKVSClient) ListKeys(ctx context.Context, key string) (res responses.KeysResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.ListKeys(ctx, key)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientKVSClient) DeleteKey(ctx context.Context, key string) (res responses.KeysResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.DeleteKey(ctx, key)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientKVSClient) DeleteKeysByPrefix(ctx context.Context, key string) (res responses.KeysResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.DeleteKeysByPrefix(ctx, key)
+		return err
+	})
+	return res, err
+}
+
+// resilientCommonClient decorates a CommonClient with the retry policy and the circuit breaker
+type resilientCommonClient struct {
+	resilientCaller
+	client interfaces.CommonClient
+}
+
+func (r *resilientCommonClient) Configuration(ctx context.Context) (res common.ConfigResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.Configuration(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientCommonClient) Ping(ctx context.Context) (res common.PingResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.Ping(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientCommonClient) Version(ctx context.Context) (res common.VersionResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.Version(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (r *resilientCommonClient) AddSecret(ctx context.Context, request common.SecretRequest) (res common.BaseResponse, err errors.EdgeX) {
+	err = r.call(ctx, func() errors.EdgeX {
+		res, err = r.client.AddSecret(ctx, request)
+		return err
+	})
+	return res, err
+}
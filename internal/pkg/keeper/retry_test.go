@@ -0,0 +1,146 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeFlakyKeeperClient(t *testing.T, optional map[string]any) (*keeperClient, *FlakyMockCoreKeeper) {
+	flakyKeeper := NewFlakyMockCoreKeeper()
+	server := flakyKeeper.Start()
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	serverPort, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:         serverUrl.Hostname(),
+		Port:         serverPort,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Optional:     optional,
+	})
+	require.NoError(t, err)
+	return client, flakyKeeper
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     time.Second,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+	assert.Equal(t, time.Second, policy.backoff(50))
+
+	policy.jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(2)
+		assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+		assert.LessOrEqual(t, wait, 200*time.Millisecond)
+	}
+}
+
+func TestNewRetryPolicyInvalidOption(t *testing.T) {
+	_, err := newRetryPolicy(types.ServiceConfig{Optional: map[string]any{types.RetryAttemptsOption: "many"}})
+	assert.Error(t, err)
+	_, err = newRetryPolicy(types.ServiceConfig{Optional: map[string]any{types.RetryStatusCodesOption: "503,bad"}})
+	assert.Error(t, err)
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, map[string]any{
+		types.RetryAttemptsOption:       3,
+		types.RetryInitialBackoffOption: "1ms",
+		types.RetryStatusCodesOption:    "502,503",
+	})
+
+	require.NoError(t, client.PutConfigurationValue("Foo", []byte("bar")))
+
+	flakyKeeper.FailNext(2, http.StatusServiceUnavailable)
+	value, err := client.GetConfigurationValue("Foo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), value)
+	assert.Equal(t, 4, flakyKeeper.Requests())
+
+	// gives up once the attempts are exhausted
+	flakyKeeper.FailNext(3, http.StatusBadGateway)
+	_, err = client.GetConfigurationValue("Foo")
+	assert.Error(t, err)
+	assert.Equal(t, 7, flakyKeeper.Requests())
+
+	// status codes which aren't retryable fail straight away
+	flakyKeeper.FailNext(1, http.StatusInternalServerError)
+	_, err = client.GetConfigurationValue("Foo")
+	assert.Error(t, err)
+	assert.Equal(t, 8, flakyKeeper.Requests())
+}
+
+func TestNoRetryByDefault(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, nil)
+
+	flakyKeeper.FailNext(1, http.StatusServiceUnavailable)
+	assert.False(t, client.IsAlive())
+	assert.Equal(t, 1, flakyKeeper.Requests())
+	assert.True(t, client.IsAlive())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, map[string]any{
+		types.CircuitBreakerThresholdOption:   2,
+		types.CircuitBreakerOpenTimeoutOption: "50ms",
+	})
+
+	flakyKeeper.FailNext(3, http.StatusServiceUnavailable)
+	assert.False(t, client.IsAlive())
+	assert.False(t, client.IsAlive())
+	assert.Equal(t, 2, flakyKeeper.Requests())
+
+	// the circuit breaker is open, so the request isn't sent
+	_, err := client.HasConfiguration()
+	assert.ErrorContains(t, err, "circuit breaker is open")
+	assert.Equal(t, 2, flakyKeeper.Requests())
+
+	// the trial request fails, so the circuit breaker opens again
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, client.IsAlive())
+	assert.Equal(t, 3, flakyKeeper.Requests())
+	assert.False(t, client.IsAlive())
+	assert.Equal(t, 3, flakyKeeper.Requests())
+
+	// the trial request succeeds, so the circuit breaker closes
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, client.IsAlive())
+	assert.True(t, client.IsAlive())
+	assert.Equal(t, 5, flakyKeeper.Requests())
+}
+
+func TestCircuitBreakerIgnoresNotFound(t *testing.T) {
+	client, flakyKeeper := makeFlakyKeeperClient(t, map[string]any{
+		types.CircuitBreakerThresholdOption: 1,
+	})
+
+	for i := 0; i < 3; i++ {
+		exists, err := client.HasConfiguration()
+		require.NoError(t, err)
+		assert.False(t, exists)
+	}
+	assert.Equal(t, 3, flakyKeeper.Requests())
+}
@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// recordAcceptedPairs gets the key-value pairs currently stored under the key prefixes matched by target, which the
+// changes rejected later are reverted to. The rejected changes of target aren't reverted if they can't be retrieved.
+func (k *keeperClient) recordAcceptedPairs(target *watchTarget) {
+	keyPrefixes, err := k.matchedKeyPrefixes(target)
+	if err != nil {
+		k.logger.debug("unable to get the configuration to revert the rejected changes to", "key", target.pattern, "error", err)
+		return
+	}
+	accepted := make(map[string][]models.KVS, len(keyPrefixes))
+	for _, keyPrefix := range keyPrefixes {
+		resp, edgexErr := k.kvsClient.ValuesByKey(context.Background(), keyPrefix)
+		if edgexErr != nil {
+			if edgexErr.Code() == http.StatusNotFound {
+				continue
+			}
+			k.logger.debug("unable to get the configuration to revert the rejected changes to", "key", keyPrefix, "error", edgexErr)
+			return
+		}
+		accepted[keyPrefix] = resp.Response
+	}
+	target.accepted = accepted
+}
+
+// accept records stored as the key-value pairs of the configuration accepted under keyPrefix.
+// Returns false if they're the ones already accepted.
+func (t *watchTarget) accept(keyPrefix string, stored []models.KVS) bool {
+	if t.accepted == nil {
+		return true
+	}
+	if previous, ok := t.accepted[keyPrefix]; ok && reflect.DeepEqual(pairValues(keyPrefix, previous), pairValues(keyPrefix, stored)) {
+		return false
+	}
+	t.accepted[keyPrefix] = stored
+	return true
+}
+
+// revertRejectedChange puts back the values last accepted under keyPrefix in place of stored, the values of the
+// rejected change. The keys which didn't exist then are deleted.
+// Returns false if reverting is disabled, or if the values last accepted under keyPrefix aren't known, e.g. for a key
+// prefix matched only after the watch started, in which case nothing is changed.
+func (k *keeperClient) revertRejectedChange(ctx context.Context, target *watchTarget, keyPrefix string, stored []models.KVS) (bool, error) {
+	accepted, ok := target.accepted[keyPrefix]
+	if !ok {
+		return false, nil
+	}
+	previous := pairValues(keyPrefix, accepted)
+	current := pairValues(keyPrefix, stored)
+
+	for _, kv := range stored {
+		value, existed := previous[kv.Key]
+		if _, ok := current[kv.Key]; !ok || (existed && reflect.DeepEqual(value, kv.Value)) {
+			continue
+		}
+		if !existed {
+			k.logger.trace("deleting the rejected configuration value", "key", kv.Key)
+			if _, edgexErr := k.kvsClient.DeleteKey(ctx, kv.Key); edgexErr != nil {
+				return false, newOperationError(kv.Key, edgexErr, "unable to revert the rejected value of %s in Core Keeper", kv.Key)
+			}
+			k.audit.record(kv.Key, kv.Value, true, nil)
+			continue
+		}
+		if err := k.revertValue(ctx, kv.Key, kv.Value, true, value); err != nil {
+			return false, err
+		}
+	}
+	for _, kv := range accepted {
+		value, ok := previous[kv.Key]
+		if _, exists := current[kv.Key]; !ok || exists {
+			continue
+		}
+		if err := k.revertValue(ctx, kv.Key, nil, false, value); err != nil {
+			return false, err
+		}
+	}
+	k.logger.debug("reverted the rejected configuration change", "keyPrefix", keyPrefix)
+	return true, nil
+}
+
+// revertValue puts back value, as stored in Core Keeper, at keyPath in place of the rejected value
+func (k *keeperClient) revertValue(ctx context.Context, keyPath string, rejectedValue any, existed bool, value any) error {
+	k.logger.trace("reverting the rejected configuration value", "key", keyPath, "value", k.logger.redact(keyPath, value))
+	_, edgexErr := k.kvsClient.UpdateValuesByKey(ctx, keyPath, false, requests.UpdateKeysRequest{Value: value})
+	if edgexErr != nil {
+		return newOperationError(keyPath, edgexErr, "unable to revert the rejected value of %s in Core Keeper", keyPath)
+	}
+	k.audit.record(keyPath, rejectedValue, existed, value)
+	return nil
+}
+
+// pairValues returns the values of the key-value pairs at keyPrefix or under it, by key
+func pairValues(keyPrefix string, pairs []models.KVS) map[string]any {
+	values := make(map[string]any, len(pairs))
+	for _, kv := range pairs {
+		if kv.Key == keyPrefix || strings.HasPrefix(kv.Key, keyPrefix+KeyDelimiter) {
+			values[kv.Key] = kv.Value
+		}
+	}
+	return values
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/google/uuid"
+)
+
+const (
+	providerType = "keeper"
+	resultOK     = "OK"
+)
+
+// The attributes of the spans
+const (
+	basePathAttribute   = "config.base_path"
+	keyAttribute        = "config.key"
+	providerAttribute   = "config.provider"
+	resultAttribute     = "config.result"
+	updatedKeyAttribute = "config.updated_key"
+	staleAttribute      = "config.stale"
+	coalescedAttribute  = "config.coalesced"
+)
+
+// spanContextKey is the key of the span of an operation in its context
+type spanContextKey struct{}
+
+// spanTracer starts the spans of the client operations and links them to the requests sent to Core Keeper.
+// The requests created by core-contracts don't carry the caller's context but only its correlation ID, so every span
+// is given its own correlation ID, which tracingAuthInjector uses to look up the trace context to put in the headers.
+type spanTracer struct {
+	tracer   types.Tracer
+	basePath string
+	// contexts maps the correlation IDs of the active spans to their context
+	contexts sync.Map
+}
+
+// newSpanTracer returns nil if tracer is nil, which disables tracing
+func newSpanTracer(tracer types.Tracer, basePath string) *spanTracer {
+	if tracer == nil {
+		return nil
+	}
+	return &spanTracer{
+		tracer:   tracer,
+		basePath: basePath,
+	}
+}
+
+// start starts the span of operation on key, and returns the context to pass to the KVS and common clients along with
+// the function which ends the span with the operation's result. It is a no-op if tracing is disabled.
+func (s *spanTracer) start(ctx context.Context, operation string, key string) (context.Context, func(error)) {
+	if s == nil {
+		return ctx, func(error) {}
+	}
+
+	ctx, span := s.tracer.StartSpan(ctx, operation, map[string]any{
+		basePathAttribute: s.basePath,
+		keyAttribute:      key,
+		providerAttribute: providerType,
+	})
+	ctx = context.WithValue(ctx, spanContextKey{}, span)
+
+	correlationId := uuid.NewString()
+	// core-contracts reads the correlation ID from the context with the header name as key
+	// nolint:staticcheck
+	ctx = context.WithValue(ctx, common.CorrelationHeader, correlationId)
+	s.contexts.Store(correlationId, ctx)
+
+	return ctx, func(err error) {
+		s.contexts.Delete(correlationId)
+		result := resultOK
+		if err != nil {
+			result = types.ErrorKind(err)
+		}
+		span.SetAttributes(map[string]any{resultAttribute: result})
+		span.End(err)
+	}
+}
+
+// inject puts the trace context of the span the request was sent for into the request headers
+func (s *spanTracer) inject(req *http.Request) {
+	ctx, ok := s.contexts.Load(req.Header.Get(common.CorrelationHeader))
+	if ok {
+		s.tracer.InjectHeaders(ctx.(context.Context), req.Header)
+	}
+}
+
+// setSpanAttributes adds attributes to the span of the operation whose context is ctx, if it's traced
+func setSpanAttributes(ctx context.Context, attributes map[string]any) {
+	if span, ok := ctx.Value(spanContextKey{}).(types.Span); ok {
+		span.SetAttributes(attributes)
+	}
+}
+
+// tracingAuthInjector decorates an AuthenticationInjector to propagate the trace context to Core Keeper
+type tracingAuthInjector struct {
+	injector interfaces.AuthenticationInjector
+	tracer   *spanTracer
+}
+
+func (t *tracingAuthInjector) AddAuthenticationData(req *http.Request) error {
+	if t.injector != nil {
+		if err := t.injector.AddAuthenticationData(req); err != nil {
+			return err
+		}
+	}
+	t.tracer.inject(req)
+	return nil
+}
+
+func (t *tracingAuthInjector) RoundTripper() http.RoundTripper {
+	if t.injector == nil {
+		return http.DefaultTransport
+	}
+	return t.injector.RoundTripper()
+}
+
+// withTracing returns the AuthenticationInjector to create the http clients with
+func withTracing(injector interfaces.AuthenticationInjector, tracer *spanTracer) interfaces.AuthenticationInjector {
+	if tracer == nil {
+		return injector
+	}
+	return &tracingAuthInjector{injector: injector, tracer: tracer}
+}
@@ -0,0 +1,177 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/oteltracing"
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// makeTracingKeeperClient creates a client whose requests to Core Keeper are recorded by the returned function
+func makeTracingKeeperClient(t *testing.T, recorder *tracetest.SpanRecorder) (*keeperClient, func() []http.Header) {
+	keeperUrl, err := url.Parse(fmt.Sprintf("http://%s:%d", testHost, port))
+	require.NoError(t, err)
+	var mutex sync.Mutex
+	var headers []http.Header
+	proxy := httputil.NewSingleHostReverseProxy(keeperUrl)
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mutex.Lock()
+		headers = append(headers, request.Header.Clone())
+		mutex.Unlock()
+		proxy.ServeHTTP(writer, request)
+	}))
+	t.Cleanup(proxyServer.Close)
+	proxyUrl, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+	proxyPort, err := strconv.Atoi(proxyUrl.Port())
+	require.NoError(t, err)
+
+	config := types.ServiceConfig{
+		Host:         proxyUrl.Hostname(),
+		Port:         proxyPort,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Tracer:       oteltracing.NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+	}
+	client, err := NewKeeperClient(config)
+	require.NoError(t, err)
+
+	return client, func() []http.Header {
+		mutex.Lock()
+		defer mutex.Unlock()
+		result := headers
+		headers = nil
+		return result
+	}
+}
+
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no ended span named %s", name)
+	return nil
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[string]attribute.Value {
+	attributes := make(map[string]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attributes[string(kv.Key)] = kv.Value
+	}
+	return attributes
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	client, requestHeaders := makeTracingKeeperClient(t, recorder)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("INFO")))
+	span := findSpan(t, recorder, "PutConfigurationValue")
+	attributes := spanAttributes(span)
+	assert.Equal(t, client.configBasePath, attributes[basePathAttribute].AsString())
+	assert.Equal(t, client.fullPath("Writable/LogLevel"), attributes[keyAttribute].AsString())
+	assert.Equal(t, providerType, attributes[providerAttribute].AsString())
+	assert.Equal(t, resultOK, attributes[resultAttribute].AsString())
+
+	// the trace context of the span is propagated to Core Keeper
+	headers := requestHeaders()
+	require.Len(t, headers, 1)
+	expected := fmt.Sprintf("00-%s-%s-01", span.SpanContext().TraceID(), span.SpanContext().SpanID())
+	assert.Equal(t, expected, headers[0].Get("traceparent"))
+
+	_, err := client.GetConfigurationValue("Missing")
+	require.Error(t, err)
+	span = findSpan(t, recorder, "GetConfigurationValue")
+	assert.Equal(t, types.ErrorKindNotFound, spanAttributes(span)[resultAttribute].AsString())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.NotEmpty(t, span.Events(), "the error should be recorded")
+	requestHeaders()
+
+	// all the requests sent for an operation carry the trace context of its span
+	_, err = client.GetConfiguration(&WritableInfo{})
+	require.NoError(t, err)
+	span = findSpan(t, recorder, "GetConfiguration")
+	expected = fmt.Sprintf("00-%s-%s-01", span.SpanContext().TraceID(), span.SpanContext().SpanID())
+	headers = requestHeaders()
+	require.Greater(t, len(headers), 1)
+	for _, header := range headers {
+		assert.Equal(t, expected, header.Get("traceparent"))
+	}
+
+	// the operation's spans aren't tracked once ended
+	client.tracer.contexts.Range(func(key, _ any) bool {
+		assert.Failf(t, "active span context not removed", "correlation ID %v", key)
+		return true
+	})
+}
+
+func TestTracingWatch(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	client, _ := makeTracingKeeperClient(t, recorder)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+	defer func() {
+		client.StopWatching()
+		assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	}()
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+
+	logLevelKey := client.fullPath("Writable/LogLevel")
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClient.publishChange(t, logLevelKey, "DEBUG")
+	receiveUpdate(t, updateChannel)
+
+	span := findSpan(t, recorder, types.WatchDecodeOperation)
+	attributes := spanAttributes(span)
+	assert.Equal(t, client.fullPath("Writable"), attributes[keyAttribute].AsString())
+	assert.Equal(t, logLevelKey, attributes[updatedKeyAttribute].AsString())
+	assert.Equal(t, resultOK, attributes[resultAttribute].AsString())
+}
+
+func TestTracingDisabled(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+	assert.Nil(t, client.tracer)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("INFO")))
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("INFO"), value)
+}
@@ -0,0 +1,444 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+// errSubscriptionClosed is reported once the message client closes the channel of the change messages
+var errSubscriptionClosed = errors.New("the subscription to the configuration changes was closed by the message client")
+
+// keyWildcard is the segment of a watched key matching any single segment, as the "+" wildcard of the topics
+const keyWildcard = "*"
+
+// watchTarget is a watched key along with the configuration struct the values under it are decoded into
+type watchTarget struct {
+	// pattern is the full path of the watched key, whose segments may be the "*" wildcard
+	pattern       string
+	configuration interface{}
+	// update returns the value sent on the update channel once the configuration under keyPrefix is updated to updated
+	update func(keyPrefix string, updated interface{}) interface{}
+	// validate, if set, is called with configuration and the updated configuration before it's applied, and rejects it
+	// by returning an error
+	validate func(old, new interface{}) error
+	// revert enables reverting the rejected changes in Core Keeper
+	revert bool
+	// accepted are the key-value pairs stored under each key prefix when its configuration was last accepted, which the
+	// rejected changes are reverted to. It's nil unless reverting the rejected changes is enabled.
+	accepted map[string][]models.KVS
+}
+
+// staticSegments returns the segments of the pattern preceding the first wildcard
+func (t *watchTarget) staticSegments() []string {
+	segments := strings.Split(t.pattern, KeyDelimiter)
+	for i, segment := range segments {
+		if segment == keyWildcard {
+			return segments[:i]
+		}
+	}
+	return segments
+}
+
+// match returns the prefix of key matched by the pattern, if any
+func (t *watchTarget) match(key string) (string, bool) {
+	patternSegments := strings.Split(t.pattern, KeyDelimiter)
+	keySegments := strings.Split(key, KeyDelimiter)
+	if len(keySegments) < len(patternSegments) {
+		return "", false
+	}
+	for i, segment := range patternSegments {
+		if segment != keyWildcard && segment != keySegments[i] {
+			return "", false
+		}
+	}
+	return strings.Join(keySegments[:len(patternSegments)], KeyDelimiter), true
+}
+
+// watchTopic returns the topic of the change messages of all the keys matched by targets, which is the longest common
+// prefix of their patterns preceding any wildcard
+func watchTopic(targets []watchTarget) string {
+	var common []string
+	for i := range targets {
+		segments := targets[i].staticSegments()
+		if i == 0 {
+			common = segments
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	return path.Join(keeperTopicPrefix, path.Join(common...), "#")
+}
+
+// watchChanges are the changes of the keys under keyPrefix, which is matched by target
+type watchChanges struct {
+	target    *watchTarget
+	keyPrefix string
+	changes   []models.KVS
+}
+
+// startWatch subscribes to the change messages of the keys of targets, and processes them in a goroutine until stop
+// receives a value or is closed. A nil update is sent first once subscribed if firstUpdate is true.
+// Returns an error if the watch can't start.
+func (k *keeperClient) startWatch(updateChannel chan<- interface{}, errorChannel chan<- error, targets []watchTarget, getMsgClientCb func() messaging.MessageClient, stop <-chan bool, firstUpdate bool) error {
+	messageClient := getMsgClientCb()
+	if messageClient == nil {
+		return errors.New("unable to use MessageClient to watch for configuration changes")
+	}
+
+	// the current configuration is the one accepted before subscribing, so that no change is mistaken for it
+	for i := range targets {
+		if targets[i].revert {
+			k.recordAcceptedPairs(&targets[i])
+		}
+	}
+
+	topic := watchTopic(targets)
+	sub, err := subscribe(messageClient, topic)
+	if err != nil {
+		k.logger.debug("failed to subscribe to the configuration changes", "topic", topic, "error", err)
+		_ = messageClient.Disconnect()
+		return err
+	}
+	k.logger.debug("watching for configuration changes", "topic", topic)
+
+	k.activity.watchStarted()
+	delivery := newWatchDelivery(k.delivery, updateChannel, errorChannel, stop, k.logger)
+	go func() {
+		defer func() {
+			delivery.close()
+			k.activity.watchStopped()
+			if sub != nil {
+				sub.close()
+			}
+			k.logger.debug("stopped watching for configuration changes", "topic", topic)
+		}()
+
+		if firstUpdate && !delivery.sendFirstUpdate() {
+			return
+		}
+
+		var pending []msgTypes.MessageEnvelope
+		// debounce fires once the window opened by the first pending message is over, it's nil while none is pending
+		var debounce <-chan time.Time
+		for {
+			var lost bool
+			select {
+			case <-stop:
+				return
+			case e := <-sub.errors:
+				if !k.deliverWatchError(delivery, e) {
+					return
+				}
+				lost = true
+			case msgEnvelope, ok := <-sub.messages:
+				if !ok {
+					if !k.deliverWatchError(delivery, errSubscriptionClosed) {
+						return
+					}
+					// stop receiving from the closed channel, the watch is only recovered by subscribing again
+					sub.messages = nil
+					lost = true
+					break
+				}
+				k.countWatchMessages(types.WatchMessageReceived, 1)
+				pending = append(pending, msgEnvelope)
+				if k.watchDebounce > 0 {
+					if debounce == nil {
+						debounce = time.After(k.watchDebounce)
+					}
+					continue
+				}
+				if !k.handleWatchMessages(pending, targets, delivery) {
+					return
+				}
+				pending = nil
+			case <-debounce:
+				debounce = nil
+				if !k.handleWatchMessages(pending, targets, delivery) {
+					return
+				}
+				pending = nil
+			}
+
+			if lost && k.resubscribePolicy != nil {
+				sub.close()
+				if sub = k.resubscribe(topic, getMsgClientCb, stop); sub == nil {
+					return
+				}
+				// the pending changes are part of the configuration fetched again
+				pending, debounce = nil, nil
+				for i := range targets {
+					if !k.resyncWatch(&targets[i], delivery) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// handleWatchMessages re-fetches and decodes the configuration under each key prefix changed by the change messages
+// received, once for all of them, and delivers it unless all its changes are outdated.
+// Returns false if the watch was stopped meanwhile.
+func (k *keeperClient) handleWatchMessages(envelopes []msgTypes.MessageEnvelope, targets []watchTarget, delivery *watchDelivery) bool {
+	var groups []*watchChanges
+	var failures []error
+	ignored := 0
+	for _, envelope := range envelopes {
+		change, err := parseWatchMessage(envelope)
+		if err != nil {
+			k.logger.debug("failed to process the configuration changes", "error", err)
+			failures = append(failures, err)
+			continue
+		}
+		matched := false
+		for i := range targets {
+			keyPrefix, ok := targets[i].match(change.Key)
+			if !ok {
+				continue
+			}
+			matched = true
+			groups = addWatchChange(groups, &targets[i], keyPrefix, change)
+		}
+		if !matched {
+			// the topic of several targets may cover other keys
+			k.logger.trace("ignoring the change of a key not watched", "key", change.Key)
+			ignored++
+		}
+	}
+	k.countWatchMessages(types.WatchMessageSkipped, ignored)
+
+	if len(groups) == 0 {
+		if len(failures) == 0 {
+			return true
+		}
+		return k.applyWatchChanges(nil, failures, targets[0].pattern, &targets[0], delivery, true)
+	}
+	for i, group := range groups {
+		// the failures are reported along with the first changes
+		if i > 0 {
+			failures = nil
+		}
+		if !k.applyWatchChanges(group.changes, failures, group.keyPrefix, group.target, delivery, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// addWatchChange adds change to the changes of keyPrefix, which are added to groups if it's the first one
+func addWatchChange(groups []*watchChanges, target *watchTarget, keyPrefix string, change models.KVS) []*watchChanges {
+	for _, group := range groups {
+		if group.target == target && group.keyPrefix == keyPrefix {
+			group.changes = append(group.changes, change)
+			return groups
+		}
+	}
+	return append(groups, &watchChanges{target: target, keyPrefix: keyPrefix, changes: []models.KVS{change}})
+}
+
+// resyncWatch re-fetches and decodes the configuration under the keys of target once the watch subscribed again, as
+// the changes made while the subscription was lost were missed, and delivers it.
+// Returns false if the watch was stopped meanwhile.
+func (k *keeperClient) resyncWatch(target *watchTarget, delivery *watchDelivery) bool {
+	keyPrefixes, err := k.matchedKeyPrefixes(target)
+	if err != nil {
+		return k.deliverWatchError(delivery, err)
+	}
+	for _, keyPrefix := range keyPrefixes {
+		// a change of keyPrefix itself is never outdated
+		if !k.applyWatchChanges([]models.KVS{{Key: keyPrefix}}, nil, keyPrefix, target, delivery, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchedKeyPrefixes returns the keys matched by the pattern of target, which is looked up in Core Keeper if it has
+// wildcards
+func (k *keeperClient) matchedKeyPrefixes(target *watchTarget) ([]string, error) {
+	staticPrefix := path.Join(target.staticSegments()...)
+	if staticPrefix == target.pattern {
+		return []string{target.pattern}, nil
+	}
+	resp, edgexErr := k.kvsClient.ListKeys(context.Background(), staticPrefix)
+	if edgexErr != nil {
+		if edgexErr.Code() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, newOperationError(staticPrefix, edgexErr, "unable to get list of keys for %s from Core Keeper", staticPrefix)
+	}
+	var keyPrefixes []string
+	for _, key := range resp.Response {
+		keyPrefix, ok := target.match(string(key))
+		if ok && (len(keyPrefixes) == 0 || keyPrefixes[len(keyPrefixes)-1] != keyPrefix) {
+			keyPrefixes = append(keyPrefixes, keyPrefix)
+		}
+	}
+	return keyPrefixes, nil
+}
+
+// applyWatchChanges decodes the configuration under keyPrefix after the changes, and delivers it along with the errors
+// of the change messages which failed to be parsed. The outcome of the messages is counted if countMessages is true.
+// Returns false if the watch was stopped meanwhile.
+func (k *keeperClient) applyWatchChanges(changes []models.KVS, failures []error, keyPrefix string, target *watchTarget, delivery *watchDelivery, countMessages bool) bool {
+	ctx, end := k.tracer.start(context.Background(), types.WatchDecodeOperation, keyPrefix)
+	start := time.Now()
+
+	var current int
+	var decoded interface{}
+	var stored []models.KVS
+	var err, revertErr error
+	if len(changes) > 0 {
+		current, decoded, stored, err = k.processWatchChanges(ctx, changes, keyPrefix, target)
+	}
+	var rejected *types.RejectedChangeError
+	if errors.As(err, &rejected) {
+		rejected.Reverted, revertErr = k.revertRejectedChange(ctx, target, keyPrefix, stored)
+	} else if current > 0 && !target.accept(keyPrefix, stored) {
+		// the configuration is back to the one last accepted, i.e. once a rejected change is reverted
+		current = 0
+	}
+	observedErr := err
+	if observedErr == nil && len(failures) > 0 {
+		observedErr = failures[0]
+	}
+	k.observeWatchDecode(start, observedErr)
+	end(observedErr)
+
+	count := func(outcome types.WatchOutcome, messages int) {
+		if countMessages {
+			k.countWatchMessages(outcome, messages)
+		}
+	}
+	count(types.WatchMessageFailed, len(failures))
+	for _, failure := range failures {
+		if !k.deliverWatchError(delivery, failure) {
+			return false
+		}
+	}
+	if err != nil {
+		k.logger.debug("failed to process the configuration changes", "keyPrefix", keyPrefix, "error", k.logger.redactError(err))
+		count(types.WatchMessageFailed, len(changes))
+		if !k.deliverWatchError(delivery, err) {
+			return false
+		}
+		if revertErr != nil {
+			return k.deliverWatchError(delivery, revertErr)
+		}
+		return true
+	}
+	count(types.WatchMessageSkipped, len(changes)-current)
+	count(types.WatchMessageDecoded, current)
+	if current > 0 {
+		// the queued updates carry the decoded configuration, as the configuration of target is updated again by the
+		// next changes while they wait for the consumer
+		updated := target.configuration
+		if delivery.policy != types.WatchDeliveryBlock {
+			updated = decoded
+		}
+		return delivery.sendUpdate(target.update(keyPrefix, updated))
+	}
+	return true
+}
+
+// subscription is the message bus subscription of a watch to the change messages published on a topic
+type subscription struct {
+	client   messaging.MessageClient
+	topic    string
+	messages chan msgTypes.MessageEnvelope
+	errors   chan error
+}
+
+// subscribe subscribes client to the change messages published on topic
+func subscribe(client messaging.MessageClient, topic string) (*subscription, error) {
+	s := &subscription{
+		client:   client,
+		topic:    topic,
+		messages: make(chan msgTypes.MessageEnvelope),
+		errors:   make(chan error),
+	}
+	topics := []msgTypes.TopicChannel{
+		{
+			Topic:    topic,
+			Messages: s.messages,
+		},
+	}
+	if err := client.Subscribe(topics, s.errors); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *subscription) close() {
+	_ = s.client.Disconnect()
+}
+
+// newResubscribePolicy returns the backoff between the attempts to subscribe again once a watch subscription is lost,
+// or nil if the watches aren't recovered
+func newResubscribePolicy(config types.ServiceConfig) (*retryPolicy, error) {
+	enabled, err := config.GetOptionalBool(types.WatchResubscribeOption, false)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	policy := &retryPolicy{jitter: types.DefaultRetryJitter}
+	if policy.initialBackoff, err = config.GetOptionalDuration(types.WatchResubscribeInitialBackoffOption, types.DefaultWatchResubscribeInitialBackoff); err != nil {
+		return nil, err
+	}
+	if policy.maxBackoff, err = config.GetOptionalDuration(types.WatchResubscribeMaxBackoffOption, types.DefaultWatchResubscribeMaxBackoff); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// resubscribe subscribes again to topic with the message client returned by getMsgClientCb, once the previous
+// subscription is lost, until it succeeds. Returns nil if the watch was stopped by stop meanwhile.
+func (k *keeperClient) resubscribe(topic string, getMsgClientCb func() messaging.MessageClient, stop <-chan bool) *subscription {
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(k.resubscribePolicy.backoff(attempt)):
+		}
+
+		client := getMsgClientCb()
+		if client == nil {
+			k.logger.debug("no message client to subscribe again to the configuration changes", "topic", topic, "attempt", attempt)
+			continue
+		}
+		s, err := subscribe(client, topic)
+		if err != nil {
+			// the client returned may be the one disconnected along with the lost subscription
+			if connectErr := client.Connect(); connectErr == nil {
+				s, err = subscribe(client, topic)
+			}
+		}
+		if err != nil {
+			k.logger.debug("failed to subscribe again to the configuration changes", "topic", topic, "attempt", attempt, "error", err)
+			_ = client.Disconnect()
+			continue
+		}
+		k.logger.debug("subscribed again to the configuration changes", "topic", topic, "attempt", attempt)
+		return s
+	}
+}
@@ -0,0 +1,681 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMessageClient is an in-process messaging.MessageClient which lets the tests publish configuration changes
+type fakeMessageClient struct {
+	messaging.MessageClient
+	mutex        sync.Mutex
+	topics       []msgTypes.TopicChannel
+	errors       chan error
+	disconnected bool
+	// subscribeErr is returned by Subscribe, and by Connect, if set
+	subscribeErr error
+}
+
+func (f *fakeMessageClient) Connect() error {
+	return f.subscribeErr
+}
+
+func (f *fakeMessageClient) Subscribe(topics []msgTypes.TopicChannel, messageErrors chan error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.subscribeErr != nil {
+		return f.subscribeErr
+	}
+	f.topics = append(f.topics, topics...)
+	f.errors = messageErrors
+	return nil
+}
+
+func (f *fakeMessageClient) Disconnect() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.disconnected = true
+	return nil
+}
+
+func (f *fakeMessageClient) isDisconnected() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.disconnected
+}
+
+// publishChange sends the change message of key to the subscribed topics
+func (f *fakeMessageClient) publishChange(t *testing.T, key string, value any) {
+	payload, err := json.Marshal(models.KVS{Key: key, StoredData: models.StoredData{Value: value}})
+	require.NoError(t, err)
+	envelope := msgTypes.MessageEnvelope{
+		ContentType: common.ContentTypeJSON,
+		Payload:     payload,
+	}
+
+	f.mutex.Lock()
+	topics := f.topics
+	f.mutex.Unlock()
+	require.NotEmpty(t, topics, "nothing subscribed")
+	for _, topic := range topics {
+		topic.Messages <- envelope
+	}
+}
+
+type WritableInfo struct {
+	LogLevel string
+	Port     int
+}
+
+type recordingMetrics struct {
+	mutex      sync.Mutex
+	operations map[string]int
+	watch      map[types.WatchOutcome]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		operations: make(map[string]int),
+		watch:      make(map[types.WatchOutcome]int),
+	}
+}
+
+func (r *recordingMetrics) ObserveOperation(operation string, _ time.Duration, _ string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.operations[operation]++
+}
+
+func (r *recordingMetrics) operationCount(operation string) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.operations[operation]
+}
+
+func (r *recordingMetrics) IncWatchMessages(outcome types.WatchOutcome) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.watch[outcome]++
+}
+
+func (r *recordingMetrics) watchCount(outcome types.WatchOutcome) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.watch[outcome]
+}
+
+func receiveUpdate(t *testing.T, updateChannel chan interface{}) interface{} {
+	select {
+	case update := <-updateChannel:
+		return update
+	case <-time.After(time.Second):
+		require.Fail(t, "no update received")
+		return nil
+	}
+}
+
+func receiveError(t *testing.T, errorChannel chan error) error {
+	select {
+	case err := <-errorChannel:
+		return err
+	case <-time.After(time.Second):
+		require.Fail(t, "no error received")
+		return nil
+	}
+}
+
+func TestWatchForChanges(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+	metrics := newRecordingMetrics()
+	client.metrics = metrics
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+	defer func() {
+		client.StopWatching()
+		assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	}()
+
+	// the first update is always nil
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+	assert.Equal(t, path.Join(keeperTopicPrefix, client.configBasePath, "Writable", "#"), messageClient.topics[0].Topic)
+	assert.Equal(t, 1, client.HealthCheck().ActiveWatches)
+
+	logLevelKey := client.fullPath("Writable/LogLevel")
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClient.publishChange(t, logLevelKey, "DEBUG")
+	update := receiveUpdate(t, updateChannel)
+	require.IsType(t, &WritableInfo{}, update)
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Port: 8080}, *update.(*WritableInfo))
+
+	// an outdated message is skipped
+	messageClient.publishChange(t, logLevelKey, "TRACE")
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("ERROR")))
+	messageClient.publishChange(t, logLevelKey, "ERROR")
+	update = receiveUpdate(t, updateChannel)
+	assert.Equal(t, "ERROR", update.(*WritableInfo).LogLevel)
+
+	// a value which can't be decoded is reported on the error channel
+	require.NoError(t, client.PutConfigurationValue("Writable/Port", []byte("abc")))
+	messageClient.publishChange(t, client.fullPath("Writable/Port"), "abc")
+	err := receiveError(t, errorChannel)
+	assert.ErrorIs(t, err, types.ErrDecode)
+
+	// errors from the message bus are passed through
+	messageClient.errors <- errors.New("message bus failure")
+	err = receiveError(t, errorChannel)
+	assert.EqualError(t, err, "message bus failure")
+
+	assert.Equal(t, 4, metrics.watchCount(types.WatchMessageReceived))
+	assert.Equal(t, 2, metrics.watchCount(types.WatchMessageDecoded))
+	assert.Equal(t, 1, metrics.watchCount(types.WatchMessageSkipped))
+	assert.Equal(t, 1, metrics.watchCount(types.WatchMessageFailed))
+	assert.Equal(t, 4, metrics.operationCount(types.WatchDecodeOperation))
+}
+
+func TestWatchForChangesDebounce(t *testing.T) {
+	metrics := newRecordingMetrics()
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:         testHost,
+		Port:         port,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Metrics:      metrics,
+		Optional:     map[string]any{types.WatchDebounceOption: "200ms"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200*time.Millisecond, client.watchDebounce)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+	defer func() {
+		client.StopWatching()
+		assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	}()
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+
+	// the burst of changes of a configuration write is coalesced into one update
+	logLevelKey := client.fullPath("Writable/LogLevel")
+	require.NoError(t, client.PutConfiguration(map[string]any{"Writable": map[string]any{"LogLevel": "DEBUG", "Port": 8081}}, true))
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("ERROR")))
+	messageClient.publishChange(t, logLevelKey, "DEBUG")
+	messageClient.publishChange(t, client.fullPath("Writable/Port"), "8081")
+	messageClient.publishChange(t, logLevelKey, "ERROR")
+	update := receiveUpdate(t, updateChannel)
+	assert.Equal(t, WritableInfo{LogLevel: "ERROR", Port: 8081}, *update.(*WritableInfo))
+	select {
+	case update = <-updateChannel:
+		assert.Fail(t, "unexpected update", "%v", update)
+	case <-time.After(2 * client.watchDebounce):
+	}
+
+	assert.Equal(t, 3, metrics.watchCount(types.WatchMessageReceived))
+	assert.Equal(t, 2, metrics.watchCount(types.WatchMessageDecoded))
+	assert.Equal(t, 1, metrics.watchCount(types.WatchMessageSkipped))
+	assert.Equal(t, 1, metrics.operationCount(types.WatchDecodeOperation))
+
+	// no update is sent if all the coalesced changes are outdated
+	messageClient.publishChange(t, logLevelKey, "TRACE")
+	messageClient.publishChange(t, logLevelKey, "WARN")
+	assert.Eventually(t, func() bool { return metrics.operationCount(types.WatchDecodeOperation) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 3, metrics.watchCount(types.WatchMessageSkipped))
+	assert.Empty(t, updateChannel)
+
+	// a message which can't be parsed doesn't prevent the update of the coalesced changes
+	require.NoError(t, client.PutConfigurationValue("Writable/Port", []byte("8082")))
+	topics := messageClient.topics
+	topics[0].Messages <- msgTypes.MessageEnvelope{ContentType: "text/plain"}
+	messageClient.publishChange(t, client.fullPath("Writable/Port"), "8082")
+	err = receiveError(t, errorChannel)
+	assert.ErrorContains(t, err, "invalid content type")
+	update = receiveUpdate(t, updateChannel)
+	assert.Equal(t, WritableInfo{LogLevel: "ERROR", Port: 8082}, *update.(*WritableInfo))
+	assert.Equal(t, 1, metrics.watchCount(types.WatchMessageFailed))
+}
+
+func TestWatchForChangesResubscribe(t *testing.T) {
+	client, err := NewKeeperClient(types.ServiceConfig{
+		Host:         testHost,
+		Port:         port,
+		BasePath:     getUniqueServiceName(),
+		AuthInjector: NewNullAuthenticationInjector(),
+		Optional: map[string]any{
+			types.WatchResubscribeOption:               true,
+			types.WatchResubscribeInitialBackoffOption: "10ms",
+			types.WatchResubscribeMaxBackoffOption:     "50ms",
+		},
+	})
+	require.NoError(t, err)
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+	// the message clients returned one after the other, the nil and the failing ones are skipped
+	failing := &fakeMessageClient{subscribeErr: errors.New("not connected")}
+	messageClients := []*fakeMessageClient{{}, nil, failing, {}, {}}
+	var calls atomic.Int32
+	getMsgClientCb := func() messaging.MessageClient {
+		messageClient := messageClients[calls.Add(1)-1]
+		if messageClient == nil {
+			return nil
+		}
+		return messageClient
+	}
+
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", getMsgClientCb)
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+
+	// the change made while the subscription is lost is caught up with once subscribed again
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClients[0].errors <- errors.New("connection lost")
+	assert.EqualError(t, receiveError(t, errorChannel), "connection lost")
+	update := receiveUpdate(t, updateChannel)
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Port: 8080}, *update.(*WritableInfo))
+	assert.Equal(t, int32(4), calls.Load())
+	assert.True(t, messageClients[0].isDisconnected())
+	assert.True(t, failing.isDisconnected())
+
+	require.NoError(t, client.PutConfigurationValue("Writable/Port", []byte("8081")))
+	messageClients[3].publishChange(t, client.fullPath("Writable/Port"), "8081")
+	update = receiveUpdate(t, updateChannel)
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Port: 8081}, *update.(*WritableInfo))
+
+	// the subscription closed by the message client is recovered as well
+	close(messageClients[3].topics[0].Messages)
+	assert.ErrorIs(t, receiveError(t, errorChannel), errSubscriptionClosed)
+	receiveUpdate(t, updateChannel)
+	assert.Equal(t, int32(5), calls.Load())
+
+	client.StopWatching()
+	assert.Eventually(t, messageClients[4].isDisconnected, time.Second, 10*time.Millisecond)
+}
+
+func TestWatchForChangesWithoutResubscribe(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+	assert.Nil(t, client.resubscribePolicy)
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	client.WatchForChanges(updateChannel, errorChannel, &WritableInfo{}, "Writable", func() messaging.MessageClient { return messageClient })
+	defer func() {
+		client.StopWatching()
+		assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	}()
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+
+	// the errors are only reported, and the watch keeps running once its subscription is closed
+	messageClient.errors <- errors.New("connection lost")
+	assert.EqualError(t, receiveError(t, errorChannel), "connection lost")
+	close(messageClient.topics[0].Messages)
+	assert.ErrorIs(t, receiveError(t, errorChannel), errSubscriptionClosed)
+	assert.False(t, messageClient.isDisconnected())
+}
+
+type ClientInfo struct {
+	Host string
+	Port int
+}
+
+func TestWatchForMultipleChanges(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+	metrics := newRecordingMetrics()
+	client.metrics = metrics
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080},
+		"Clients": map[string]any{
+			"core-data":     map[string]any{"Host": "localhost", "Port": 59880},
+			"core-metadata": map[string]any{"Host": "localhost", "Port": 59881},
+		},
+		"Other": "value",
+	}, true))
+
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{})
+	errorChannel := make(chan error)
+	targets := []types.WatchTarget{
+		{WaitKey: "Writable", Configuration: &WritableInfo{}},
+		{WaitKey: "Clients/*", Configuration: &ClientInfo{}},
+	}
+	client.WatchForMultipleChanges(updateChannel, errorChannel, targets, func() messaging.MessageClient { return messageClient })
+	defer func() {
+		client.StopWatching()
+		assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	}()
+
+	// a single subscription covers all the targets
+	require.Eventually(t, func() bool { return client.HealthCheck().ActiveWatches == 1 }, time.Second, 10*time.Millisecond)
+	require.Len(t, messageClient.topics, 1)
+	assert.Equal(t, path.Join(keeperTopicPrefix, client.configBasePath, "#"), messageClient.topics[0].Topic)
+
+	require.NoError(t, client.PutConfigurationValue("Clients/core-metadata/Port", []byte("59891")))
+	messageClient.publishChange(t, client.fullPath("Clients/core-metadata/Port"), "59891")
+	update := receiveUpdate(t, updateChannel)
+	require.IsType(t, types.WatchUpdate{}, update)
+	assert.Equal(t, "Clients/*", update.(types.WatchUpdate).WaitKey)
+	assert.Equal(t, client.fullPath("Clients/core-metadata"), update.(types.WatchUpdate).Key)
+	assert.Equal(t, &ClientInfo{Host: "localhost", Port: 59891}, update.(types.WatchUpdate).Configuration)
+
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClient.publishChange(t, client.fullPath("Writable/LogLevel"), "DEBUG")
+	update = receiveUpdate(t, updateChannel)
+	assert.Equal(t, types.WatchUpdate{
+		WaitKey:       "Writable",
+		Key:           client.fullPath("Writable"),
+		Configuration: &WritableInfo{LogLevel: "DEBUG", Port: 8080},
+	}, update)
+
+	// the changes of the keys not watched are skipped
+	require.NoError(t, client.PutConfigurationValue("Other", []byte("changed")))
+	messageClient.publishChange(t, client.fullPath("Other"), "changed")
+	assert.Eventually(t, func() bool { return metrics.watchCount(types.WatchMessageSkipped) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, updateChannel)
+	assert.Equal(t, 3, metrics.watchCount(types.WatchMessageReceived))
+	assert.Equal(t, 2, metrics.watchCount(types.WatchMessageDecoded))
+
+	// no target to watch, the error is sent without blocking the caller
+	errorChannel = make(chan error)
+	client.WatchForMultipleChanges(updateChannel, errorChannel, nil, func() messaging.MessageClient { return messageClient })
+	assert.ErrorContains(t, receiveError(t, errorChannel), "no key to watch")
+}
+
+func TestWatchSetupErrorStopped(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// the error of the watch failing to start isn't read
+	errorChannel := make(chan error)
+	client.WatchForChanges(make(chan interface{}), errorChannel, &TestConfig{}, "Writable", func() messaging.MessageClient { return nil })
+
+	// StopWatching still stops the watch running
+	messageClient := &fakeMessageClient{}
+	updateChannel := make(chan interface{}, 10)
+	client.WatchForChanges(updateChannel, make(chan error, 10), &TestConfig{}, "Writable", func() messaging.MessageClient { return messageClient })
+	assert.Nil(t, receiveUpdate(t, updateChannel))
+	client.StopWatching()
+	assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+
+	// the error is dropped once the client is closed
+	require.NoError(t, client.Close())
+	assert.Never(t, func() bool {
+		select {
+		case <-errorChannel:
+			return true
+		default:
+			return false
+		}
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestWatchTopic(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		expected string
+	}{
+		{"single key", []string{"edgex/v4/core-data/Writable"}, "edgex/configs/edgex/v4/core-data/Writable/#"},
+		{"sibling keys", []string{"edgex/v4/core-data/Writable", "edgex/v4/core-data/Clients"}, "edgex/configs/edgex/v4/core-data/#"},
+		{"nested keys", []string{"edgex/v4/core-data/Writable", "edgex/v4/core-data/Writable/Telemetry"}, "edgex/configs/edgex/v4/core-data/Writable/#"},
+		{"wildcard", []string{"edgex/v4/core-data/Clients/*/Host"}, "edgex/configs/edgex/v4/core-data/Clients/#"},
+		{"other service", []string{"edgex/v4/core-data/Writable", "edgex/v4/core-common-config-bootstrapper/all-services"}, "edgex/configs/edgex/v4/#"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var targets []watchTarget
+			for _, pattern := range test.patterns {
+				targets = append(targets, watchTarget{pattern: pattern})
+			}
+			assert.Equal(t, test.expected, watchTopic(targets))
+		})
+	}
+}
+
+func TestWatchTargetMatch(t *testing.T) {
+	target := watchTarget{pattern: "edgex/v4/core-data/Clients/*"}
+
+	keyPrefix, ok := target.match("edgex/v4/core-data/Clients/core-metadata/Port")
+	assert.True(t, ok)
+	assert.Equal(t, "edgex/v4/core-data/Clients/core-metadata", keyPrefix)
+	keyPrefix, ok = target.match("edgex/v4/core-data/Clients/core-metadata")
+	assert.True(t, ok)
+	assert.Equal(t, "edgex/v4/core-data/Clients/core-metadata", keyPrefix)
+
+	_, ok = target.match("edgex/v4/core-data/Clients")
+	assert.False(t, ok)
+	_, ok = target.match("edgex/v4/core-data/Writable/LogLevel")
+	assert.False(t, ok)
+}
+
+// ValidatedWritableInfo rejects the unknown log levels
+type ValidatedWritableInfo struct {
+	LogLevel string
+	Port     int
+}
+
+func (v *ValidatedWritableInfo) Validate() error {
+	switch v.LogLevel {
+	case "INFO", "DEBUG", "ERROR":
+		return nil
+	default:
+		return fmt.Errorf("unknown log level %q", v.LogLevel)
+	}
+}
+
+func TestWatchForChangesValidation(t *testing.T) {
+	for _, revert := range []bool{false, true} {
+		t.Run(fmt.Sprintf("revert %t", revert), func(t *testing.T) {
+			client, err := NewKeeperClient(types.ServiceConfig{
+				Host:         testHost,
+				Port:         port,
+				BasePath:     getUniqueServiceName(),
+				AuthInjector: NewNullAuthenticationInjector(),
+				Optional:     map[string]any{types.WatchRevertRejectedOption: revert},
+			})
+			require.NoError(t, err)
+			metrics := newRecordingMetrics()
+			client.metrics = metrics
+
+			// delete the configuration created
+			defer reset(t, client)
+
+			require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+			messageClient := &fakeMessageClient{}
+			updateChannel := make(chan interface{})
+			errorChannel := make(chan error)
+			configuration := &ValidatedWritableInfo{}
+			client.WatchForChanges(updateChannel, errorChannel, configuration, "Writable", func() messaging.MessageClient { return messageClient })
+			defer func() {
+				client.StopWatching()
+				assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+			}()
+			assert.Nil(t, receiveUpdate(t, updateChannel))
+
+			logLevelKey := client.fullPath("Writable/LogLevel")
+			require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+			messageClient.publishChange(t, logLevelKey, "DEBUG")
+			assert.Equal(t, &ValidatedWritableInfo{LogLevel: "DEBUG", Port: 8080}, receiveUpdate(t, updateChannel))
+
+			// the rejected configuration isn't applied, and the previous good one is kept
+			require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("VERBOSE")))
+			messageClient.publishChange(t, logLevelKey, "VERBOSE")
+			err = receiveError(t, errorChannel)
+			assert.ErrorIs(t, err, types.ErrRejected)
+			var rejected *types.RejectedChangeError
+			require.ErrorAs(t, err, &rejected)
+			assert.Equal(t, client.fullPath("Writable"), rejected.KeyPath)
+			assert.EqualError(t, rejected.Err, `unknown log level "VERBOSE"`)
+			assert.Equal(t, revert, rejected.Reverted)
+			assert.Equal(t, ValidatedWritableInfo{LogLevel: "DEBUG", Port: 8080}, *configuration)
+			assert.Equal(t, 1, metrics.watchCount(types.WatchMessageFailed))
+
+			value, err := client.GetConfigurationValue("Writable/LogLevel")
+			require.NoError(t, err)
+			if !revert {
+				assert.Equal(t, "VERBOSE", string(value))
+				return
+			}
+			assert.Equal(t, "DEBUG", string(value))
+
+			// the change message of the reverted value doesn't update the configuration again
+			messageClient.publishChange(t, logLevelKey, "DEBUG")
+			assert.Eventually(t, func() bool { return metrics.watchCount(types.WatchMessageSkipped) == 1 }, time.Second, 10*time.Millisecond)
+			assert.Empty(t, updateChannel)
+
+			require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("ERROR")))
+			messageClient.publishChange(t, logLevelKey, "ERROR")
+			assert.Equal(t, &ValidatedWritableInfo{LogLevel: "ERROR", Port: 8080}, receiveUpdate(t, updateChannel))
+		})
+	}
+}
+
+func TestRevertRejectedChange(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+	target := &watchTarget{pattern: client.fullPath("Writable")}
+	client.recordAcceptedPairs(target)
+	require.Len(t, target.accepted[target.pattern], 2)
+
+	// the rejected change updated a value, deleted another one and added a new one
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("VERBOSE")))
+	require.NoError(t, client.DeleteConfigurationValue("Writable/Port", false))
+	require.NoError(t, client.PutConfigurationValue("Writable/Extra", []byte("value")))
+	resp, edgexErr := client.kvsClient.ValuesByKey(context.Background(), target.pattern)
+	require.NoError(t, edgexErr)
+
+	reverted, err := client.revertRejectedChange(context.Background(), target, target.pattern, resp.Response)
+	require.NoError(t, err)
+	assert.True(t, reverted)
+	keys, err := client.GetConfigurationKeys("Writable")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{client.fullPath("Writable/LogLevel"), client.fullPath("Writable/Port")}, keys)
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, "INFO", string(value))
+	value, err = client.GetConfigurationValue("Writable/Port")
+	require.NoError(t, err)
+	assert.Equal(t, "8080", string(value))
+
+	// nothing is reverted if the values last accepted aren't known
+	reverted, err = client.revertRejectedChange(context.Background(), &watchTarget{pattern: target.pattern}, target.pattern, resp.Response)
+	require.NoError(t, err)
+	assert.False(t, reverted)
+
+	// nor if the key prefix wasn't stored when the values were recorded, whose rejected keys are kept
+	require.NoError(t, client.PutConfigurationValue("Writable/Extra", []byte("value")))
+	resp, edgexErr = client.kvsClient.ValuesByKey(context.Background(), target.pattern)
+	require.NoError(t, edgexErr)
+	unseeded := &watchTarget{pattern: target.pattern, accepted: map[string][]models.KVS{}}
+	reverted, err = client.revertRejectedChange(context.Background(), unseeded, target.pattern, resp.Response)
+	require.NoError(t, err)
+	assert.False(t, reverted)
+	keys, err = client.GetConfigurationKeys("Writable")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{client.fullPath("Writable/LogLevel"), client.fullPath("Writable/Port"), client.fullPath("Writable/Extra")}, keys)
+}
+
+func TestWatchForChangesWithHandler(t *testing.T) {
+	client := makeCoreKeeperClient(getUniqueServiceName())
+
+	// delete the configuration created
+	defer reset(t, client)
+
+	require.NoError(t, client.PutConfigurationMap(map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}}, true))
+
+	type change struct {
+		old, new WritableInfo
+	}
+	changes := make(chan change, 10)
+	errorChannel := make(chan error, 10)
+	messageClient := &fakeMessageClient{}
+	configuration := &WritableInfo{}
+	stop, err := client.WatchForChangesWithHandler(configuration, "Writable", func(old, new interface{}) error {
+		changes <- change{old: *old.(*WritableInfo), new: *new.(*WritableInfo)}
+		if new.(*WritableInfo).LogLevel == "VERBOSE" {
+			return errors.New("log level not supported")
+		}
+		return nil
+	}, func(err error) { errorChannel <- err }, func() messaging.MessageClient { return messageClient })
+	require.NoError(t, err)
+	assert.Equal(t, WritableInfo{LogLevel: "INFO", Port: 8080}, *configuration)
+
+	// the watch isn't stopped by StopWatching, which stops the other watches
+	client.StopWatching()
+	defer func() { <-client.watchingDone }()
+
+	logLevelKey := client.fullPath("Writable/LogLevel")
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("DEBUG")))
+	messageClient.publishChange(t, logLevelKey, "DEBUG")
+	assert.Equal(t, change{old: WritableInfo{LogLevel: "INFO", Port: 8080}, new: WritableInfo{LogLevel: "DEBUG", Port: 8080}}, <-changes)
+
+	// the rejected change is reverted in Core Keeper
+	require.NoError(t, client.PutConfigurationValue("Writable/LogLevel", []byte("VERBOSE")))
+	messageClient.publishChange(t, logLevelKey, "VERBOSE")
+	assert.Equal(t, change{old: WritableInfo{LogLevel: "DEBUG", Port: 8080}, new: WritableInfo{LogLevel: "VERBOSE", Port: 8080}}, <-changes)
+	err = receiveError(t, errorChannel)
+	var rejected *types.RejectedChangeError
+	require.ErrorAs(t, err, &rejected)
+	assert.True(t, rejected.Reverted)
+	assert.EqualError(t, rejected.Err, "log level not supported")
+	assert.Equal(t, WritableInfo{LogLevel: "DEBUG", Port: 8080}, *configuration)
+	value, err := client.GetConfigurationValue("Writable/LogLevel")
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", string(value))
+
+	stop()
+	assert.Eventually(t, messageClient.isDisconnected, time.Second, 10*time.Millisecond)
+	assert.Empty(t, changes)
+	stop()
+
+	_, err = client.WatchForChangesWithHandler(&WritableInfo{}, "Missing", nil, nil, func() messaging.MessageClient { return messageClient })
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	_, err = client.WatchForChangesWithHandler(&WritableInfo{}, "Writable", nil, nil, func() messaging.MessageClient { return nil })
+	assert.EqualError(t, err, "unable to use MessageClient to watch for configuration changes")
+}
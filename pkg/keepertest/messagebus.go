@@ -0,0 +1,241 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+)
+
+// ErrNotSupported is returned by the operations the in-process message clients don't implement
+var ErrNotSupported = errors.New("not supported by the in-process message client")
+
+// ErrDisconnected is returned by the operations of a disconnected message client
+var ErrDisconnected = errors.New("message client disconnected")
+
+// MessageBus is an in-process message bus, whose clients receive the messages published by any of them on the topics
+// they subscribed to. The topics support the MQTT wildcards, i.e. "#" and "+".
+type MessageBus struct {
+	mutex   sync.RWMutex
+	clients map[*messageClient]struct{}
+}
+
+// NewMessageBus creates a message bus without clients
+func NewMessageBus() *MessageBus {
+	return &MessageBus{clients: make(map[*messageClient]struct{})}
+}
+
+// NewClient creates a connected client of the bus. The messages are delivered to its subscriptions in order, without
+// blocking the publisher, until they are unsubscribed or the client is disconnected, which closes no channel.
+func (b *MessageBus) NewClient() messaging.MessageClient {
+	client := &messageClient{bus: b}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.clients[client] = struct{}{}
+	return client
+}
+
+// Publish sends message to the subscriptions matching topic
+func (b *MessageBus) Publish(message types.MessageEnvelope, topic string) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	message.ReceivedTopic = topic
+	for client := range b.clients {
+		client.deliver(message, topic)
+	}
+}
+
+func (b *MessageBus) remove(client *messageClient) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.clients, client)
+}
+
+// subscription forwards the messages received on a topic to the subscriber's channel from its own goroutine, so that
+// a slow subscriber doesn't block the publisher nor the other subscribers
+type subscription struct {
+	topic    string
+	messages chan types.MessageEnvelope
+	mutex    sync.Mutex
+	queue    []types.MessageEnvelope
+	ready    chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newSubscription(topic string, messages chan types.MessageEnvelope) *subscription {
+	s := &subscription{
+		topic:    topic,
+		messages: messages,
+		ready:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go s.forward()
+	return s
+}
+
+func (s *subscription) enqueue(message types.MessageEnvelope) {
+	s.mutex.Lock()
+	s.queue = append(s.queue, message)
+	s.mutex.Unlock()
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscription) forward() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ready:
+		}
+		for {
+			s.mutex.Lock()
+			if len(s.queue) == 0 {
+				s.mutex.Unlock()
+				break
+			}
+			message := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mutex.Unlock()
+
+			select {
+			case <-s.done:
+				return
+			case s.messages <- message:
+			}
+		}
+	}
+}
+
+func (s *subscription) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// messageClient is a client of MessageBus
+type messageClient struct {
+	bus           *MessageBus
+	mutex         sync.Mutex
+	subscriptions []*subscription
+	disconnected  bool
+}
+
+func (c *messageClient) deliver(message types.MessageEnvelope, topic string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, s := range c.subscriptions {
+		if matchesTopic(s.topic, topic) {
+			s.enqueue(message)
+		}
+	}
+}
+
+func (c *messageClient) Connect() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.disconnected {
+		return ErrDisconnected
+	}
+	return nil
+}
+
+func (c *messageClient) Publish(message types.MessageEnvelope, topic string) error {
+	c.mutex.Lock()
+	disconnected := c.disconnected
+	c.mutex.Unlock()
+	if disconnected {
+		return ErrDisconnected
+	}
+	c.bus.Publish(message, topic)
+	return nil
+}
+
+func (c *messageClient) PublishWithSizeLimit(message types.MessageEnvelope, topic string, _ int64) error {
+	return c.Publish(message, topic)
+}
+
+func (c *messageClient) Subscribe(topics []types.TopicChannel, _ chan error) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.disconnected {
+		return ErrDisconnected
+	}
+	for _, topic := range topics {
+		c.subscriptions = append(c.subscriptions, newSubscription(topic.Topic, topic.Messages))
+	}
+	return nil
+}
+
+func (c *messageClient) Request(types.MessageEnvelope, string, string, time.Duration) (*types.MessageEnvelope, error) {
+	return nil, ErrNotSupported
+}
+
+func (c *messageClient) PublishBinaryData(data []byte, topic string) error {
+	return c.Publish(types.MessageEnvelope{Payload: data}, topic)
+}
+
+func (c *messageClient) SubscribeBinaryData(topics []types.TopicChannel, messageErrors chan error) error {
+	return c.Subscribe(topics, messageErrors)
+}
+
+func (c *messageClient) Unsubscribe(topics ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	remaining := c.subscriptions[:0]
+	for _, s := range c.subscriptions {
+		unsubscribed := false
+		for _, topic := range topics {
+			if s.topic == topic {
+				unsubscribed = true
+				break
+			}
+		}
+		if unsubscribed {
+			s.stop()
+		} else {
+			remaining = append(remaining, s)
+		}
+	}
+	c.subscriptions = remaining
+	return nil
+}
+
+func (c *messageClient) Disconnect() error {
+	c.mutex.Lock()
+	c.disconnected = true
+	for _, s := range c.subscriptions {
+		s.stop()
+	}
+	c.subscriptions = nil
+	c.mutex.Unlock()
+	c.bus.remove(c)
+	return nil
+}
+
+// matchesTopic checks if topic matches the subscribed filter, which may contain the MQTT wildcards
+func matchesTopic(filter string, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, messages chan types.MessageEnvelope) types.MessageEnvelope {
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(time.Second):
+		require.Fail(t, "no message received")
+		return types.MessageEnvelope{}
+	}
+}
+
+func assertNothingReceived(t *testing.T, messages chan types.MessageEnvelope) {
+	select {
+	case message := <-messages:
+		assert.Failf(t, "unexpected message", "received on %s", message.ReceivedTopic)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMatchesTopic(t *testing.T) {
+	tests := []struct {
+		filter   string
+		topic    string
+		expected bool
+	}{
+		{"edgex/configs/svc", "edgex/configs/svc", true},
+		{"edgex/configs/svc", "edgex/configs/svc/Writable", false},
+		{"edgex/configs/svc/#", "edgex/configs/svc/Writable/LogLevel", true},
+		{"edgex/configs/svc/#", "edgex/configs/svc", true},
+		{"edgex/configs/svc/#", "edgex/configs/svcx/Writable", false},
+		{"edgex/configs/+/Writable", "edgex/configs/svc/Writable", true},
+		{"edgex/configs/+/Writable", "edgex/configs/svc/Writable/LogLevel", false},
+		{"edgex/configs/+", "edgex/configs", false},
+	}
+	for _, test := range tests {
+		t.Run(test.filter+" "+test.topic, func(t *testing.T) {
+			assert.Equal(t, test.expected, matchesTopic(test.filter, test.topic))
+		})
+	}
+}
+
+func TestMessageBus(t *testing.T) {
+	bus := NewMessageBus()
+	publisher := bus.NewClient()
+	subscriber := bus.NewClient()
+	other := bus.NewClient()
+
+	messages := make(chan types.MessageEnvelope)
+	otherMessages := make(chan types.MessageEnvelope, 1)
+	require.NoError(t, subscriber.Subscribe([]types.TopicChannel{{Topic: "edgex/configs/svc/#", Messages: messages}}, nil))
+	require.NoError(t, other.Subscribe([]types.TopicChannel{{Topic: "edgex/configs/other/#", Messages: otherMessages}}, nil))
+
+	// the publisher isn't blocked by the subscriber and the messages are received in order
+	for i := 0; i < 10; i++ {
+		require.NoError(t, publisher.Publish(types.MessageEnvelope{Payload: []byte(strconv.Itoa(i))}, "edgex/configs/svc/Writable"))
+	}
+	for i := 0; i < 10; i++ {
+		message := receive(t, messages)
+		assert.Equal(t, []byte(strconv.Itoa(i)), message.Payload)
+		assert.Equal(t, "edgex/configs/svc/Writable", message.ReceivedTopic)
+	}
+	assertNothingReceived(t, otherMessages)
+
+	// disconnecting a client doesn't affect the others
+	require.NoError(t, other.Disconnect())
+	assert.ErrorIs(t, other.Publish(types.MessageEnvelope{}, "edgex/configs/svc/Writable"), ErrDisconnected)
+	require.NoError(t, publisher.Publish(types.MessageEnvelope{}, "edgex/configs/other/Writable"))
+	require.NoError(t, publisher.Publish(types.MessageEnvelope{}, "edgex/configs/svc/Writable"))
+	receive(t, messages)
+	assertNothingReceived(t, otherMessages)
+
+	require.NoError(t, subscriber.Unsubscribe("edgex/configs/svc/#"))
+	require.NoError(t, publisher.Publish(types.MessageEnvelope{}, "edgex/configs/svc/Writable"))
+	assertNothingReceived(t, messages)
+
+	_, err := publisher.Request(types.MessageEnvelope{}, "request", "response", time.Second)
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestMessageBusConcurrency(t *testing.T) {
+	bus := NewMessageBus()
+	subscriber := bus.NewClient()
+	messages := make(chan types.MessageEnvelope)
+	require.NoError(t, subscriber.Subscribe([]types.TopicChannel{{Topic: "#", Messages: messages}}, nil))
+
+	const publishers, count = 5, 20
+	var wg sync.WaitGroup
+	for i := 0; i < publishers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			publisher := bus.NewClient()
+			defer func() { _ = publisher.Disconnect() }()
+			for j := 0; j < count; j++ {
+				assert.NoError(t, publisher.Publish(types.MessageEnvelope{}, "topic"))
+			}
+		}()
+	}
+	for i := 0; i < publishers*count; i++ {
+		receive(t, messages)
+	}
+	wg.Wait()
+
+	// the forwarding stops on disconnect even if nobody receives the messages anymore
+	require.NoError(t, bus.NewClient().Publish(types.MessageEnvelope{}, "topic"))
+	require.NoError(t, subscriber.Disconnect())
+}
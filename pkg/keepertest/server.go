@@ -0,0 +1,306 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keepertest provides a fake EdgeX Core Keeper and an in-process message bus, so that the configuration
+// client, including WatchForChanges, can be tested end-to-end without real infrastructure.
+package keepertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/edgexfoundry/go-mod-messaging/v4/messaging"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	// ServiceName is the service name reported by the ping API
+	ServiceName = "core-keeper"
+	// TopicPrefix is the prefix of the topics the configuration changes are published on, followed by the key
+	TopicPrefix = "edgex/configs"
+
+	keyRoutePrefix  = common.ApiKVSRoute + "/" + common.Key + "/"
+	prefixMatch     = "prefixMatch"
+	bearerPrefix    = "Bearer "
+	authorization   = "Authorization"
+	contentTypeJSON = common.ContentTypeJSON
+)
+
+// Server is a fake Core Keeper implementing the KVS and ping APIs, which is safe for concurrent use.
+// The values are stored as strings when flattened, like Core Keeper does, and every updated key is published to the
+// message client set with SetMessageClient on the edgex/configs/<key> topic.
+type Server struct {
+	mutex         sync.RWMutex
+	store         map[string]models.KVS
+	authToken     string
+	messageClient messaging.MessageClient
+	logger        *slog.Logger
+}
+
+// NewServer creates an empty fake Core Keeper
+func NewServer() *Server {
+	return &Server{
+		store:  make(map[string]models.KVS),
+		logger: slog.Default(),
+	}
+}
+
+// Start starts serving the fake Core Keeper over HTTP, the returned server must be closed by the caller
+func (s *Server) Start() *httptest.Server {
+	return httptest.NewServer(s)
+}
+
+// Reset deletes all the keys
+func (s *Server) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.store = make(map[string]models.KVS)
+}
+
+// SetAuthToken requires the requests to the KVS API to carry the token as "Authorization: Bearer <token>", the
+// requests without it are rejected with 401. The authentication is disabled if token is empty.
+func (s *Server) SetAuthToken(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.authToken = token
+}
+
+// SetMessageClient sets the message client the configuration changes are published with, i.e. a client of
+// MessageBus. The changes aren't published if client is nil.
+func (s *Server) SetMessageClient(client messaging.MessageClient) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.messageClient = client
+}
+
+// Get returns the stored entry of key
+func (s *Server) Get(key string) (models.KVS, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	kv, found := s.store[key]
+	return kv, found
+}
+
+// Keys returns the sorted keys stored
+func (s *Server) Keys() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	keys := make([]string, 0, len(s.store))
+	for key := range s.store {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch {
+	case request.URL.Path == common.ApiPingRoute && request.Method == http.MethodGet:
+		s.writeResponse(writer, http.StatusOK, dtoCommon.NewPingResponse(ServiceName))
+	case strings.HasPrefix(request.URL.Path, keyRoutePrefix):
+		if !s.authorized(request) {
+			s.writeError(writer, http.StatusUnauthorized, "missing or invalid authentication token")
+			return
+		}
+		key := strings.TrimPrefix(request.URL.Path, keyRoutePrefix)
+		switch request.Method {
+		case http.MethodGet:
+			s.handleGet(writer, request, key)
+		case http.MethodPut:
+			s.handlePut(writer, request, key)
+		case http.MethodDelete:
+			s.handleDelete(writer, request, key)
+		default:
+			s.writeError(writer, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", request.Method))
+		}
+	default:
+		s.writeError(writer, http.StatusNotFound, fmt.Sprintf("route %s not found", request.URL.Path))
+	}
+}
+
+func (s *Server) authorized(request *http.Request) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.authToken == "" || request.Header.Get(authorization) == bearerPrefix+s.authToken
+}
+
+func (s *Server) handleGet(writer http.ResponseWriter, request *http.Request, key string) {
+	kvs := s.lookup(key)
+	if len(kvs) == 0 {
+		s.writeError(writer, http.StatusNotFound, fmt.Sprintf("query key %s not found", key))
+		return
+	}
+
+	if queryBool(request, common.KeyOnly) {
+		keys := make([]models.KeyOnly, 0, len(kvs))
+		for _, kv := range kvs {
+			keys = append(keys, models.KeyOnly(kv.Key))
+		}
+		s.writeResponse(writer, http.StatusOK, responses.NewKeysResponse("", "", http.StatusOK, keys))
+		return
+	}
+	s.writeResponse(writer, http.StatusOK, responses.MultiKeyValueResponse{
+		BaseResponse: dtoCommon.NewBaseResponse("", "", http.StatusOK),
+		Response:     kvs,
+	})
+}
+
+func (s *Server) handlePut(writer http.ResponseWriter, request *http.Request, key string) {
+	var updateRequest requests.UpdateKeysRequest
+	if err := json.NewDecoder(request.Body).Decode(&updateRequest); err != nil {
+		s.writeError(writer, http.StatusBadRequest, fmt.Sprintf("failed to decode the request body: %v", err))
+		return
+	}
+
+	var updated []models.KVS
+	if queryBool(request, common.Flatten) {
+		updated = s.update(flatten(key, updateRequest.Value))
+	} else {
+		updated = s.update([]models.KVS{{Key: key, StoredData: models.StoredData{Value: updateRequest.Value}}})
+	}
+
+	keys := make([]models.KeyOnly, 0, len(updated))
+	for _, kv := range updated {
+		keys = append(keys, models.KeyOnly(kv.Key))
+	}
+	s.writeResponse(writer, http.StatusOK, responses.NewKeysResponse("", "", http.StatusOK, keys))
+	s.publish(updated)
+}
+
+func (s *Server) handleDelete(writer http.ResponseWriter, request *http.Request, key string) {
+	deleted := s.delete(key, queryBool(request, prefixMatch))
+	if len(deleted) == 0 {
+		s.writeError(writer, http.StatusNotFound, fmt.Sprintf("query key %s not found", key))
+		return
+	}
+	s.writeResponse(writer, http.StatusOK, responses.NewKeysResponse("", "", http.StatusOK, deleted))
+}
+
+// lookup returns the sorted entries of key and of the keys under it
+func (s *Server) lookup(key string) []models.KVS {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var kvs []models.KVS
+	for storedKey, kv := range s.store {
+		if isUnder(storedKey, key) {
+			kvs = append(kvs, kv)
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs
+}
+
+// update stores the values and sets their timestamps, and returns the stored entries
+func (s *Server) update(kvs []models.KVS) []models.KVS {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now().UnixMilli()
+	updated := make([]models.KVS, 0, len(kvs))
+	for _, kv := range kvs {
+		kv.Modified = now
+		kv.Created = now
+		if existing, found := s.store[kv.Key]; found {
+			kv.Created = existing.Created
+		}
+		s.store[kv.Key] = kv
+		updated = append(updated, kv)
+	}
+	return updated
+}
+
+// delete deletes key, and the keys under it if deep is true, and returns the sorted deleted keys
+func (s *Server) delete(key string, deep bool) []models.KeyOnly {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var deleted []models.KeyOnly
+	for storedKey := range s.store {
+		if storedKey == key || (deep && isUnder(storedKey, key)) {
+			delete(s.store, storedKey)
+			deleted = append(deleted, models.KeyOnly(storedKey))
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool { return deleted[i] < deleted[j] })
+	return deleted
+}
+
+// publish publishes the updated entries to the message client, if any
+func (s *Server) publish(kvs []models.KVS) {
+	s.mutex.RLock()
+	client := s.messageClient
+	s.mutex.RUnlock()
+	if client == nil {
+		return
+	}
+
+	for _, kv := range kvs {
+		payload, err := json.Marshal(kv)
+		if err != nil {
+			s.logger.Error("failed to encode the configuration change", "key", kv.Key, "error", err)
+			continue
+		}
+		envelope := msgTypes.NewMessageEnvelopeForRequest(payload, nil)
+		envelope.ContentType = contentTypeJSON
+		if err = client.Publish(envelope, path.Join(TopicPrefix, kv.Key)); err != nil {
+			s.logger.Error("failed to publish the configuration change", "key", kv.Key, "error", err)
+		}
+	}
+}
+
+func (s *Server) writeResponse(writer http.ResponseWriter, statusCode int, response any) {
+	writer.Header().Set(common.ContentType, contentTypeJSON)
+	writer.WriteHeader(statusCode)
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		s.logger.Error("failed to write the response", "error", err)
+	}
+}
+
+func (s *Server) writeError(writer http.ResponseWriter, statusCode int, message string) {
+	s.writeResponse(writer, statusCode, dtoCommon.NewBaseResponse("", message, statusCode))
+}
+
+// isUnder checks if key starts with prefix. Like Core Keeper, the prefix isn't matched segment by segment, i.e. both
+// "a/b" and "ab" are under "a", so that the clients are tested for filtering out the keys of the sibling segments.
+func isUnder(key string, prefix string) bool {
+	return strings.HasPrefix(key, prefix)
+}
+
+func queryBool(request *http.Request, name string) bool {
+	value, err := strconv.ParseBool(request.URL.Query().Get(name))
+	return err == nil && value
+}
+
+// flatten converts the maps and slices of value to the key-value pairs of their leaves, whose values are strings
+func flatten(key string, value any) []models.KVS {
+	var kvs []models.KVS
+	switch v := value.(type) {
+	case map[string]any:
+		for name, item := range v {
+			kvs = append(kvs, flatten(path.Join(key, name), item)...)
+		}
+	case []any:
+		for index, item := range v {
+			kvs = append(kvs, flatten(path.Join(key, strconv.Itoa(index)), item)...)
+		}
+	default:
+		kvs = append(kvs, models.KVS{Key: key, StoredData: models.StoredData{Value: cast.ToString(v)}})
+	}
+	return kvs
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package keepertest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	httpClient "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/http"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	msgTypes "github.com/edgexfoundry/go-mod-messaging/v4/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenInjector struct {
+	token string
+}
+
+func (i tokenInjector) AddAuthenticationData(req *http.Request) error {
+	if i.token != "" {
+		req.Header.Set(authorization, bearerPrefix+i.token)
+	}
+	return nil
+}
+
+func (i tokenInjector) RoundTripper() http.RoundTripper {
+	return nil
+}
+
+func TestServerKVS(t *testing.T) {
+	keeper := NewServer()
+	server := keeper.Start()
+	defer server.Close()
+	client := httpClient.NewKVSClient(server.URL, tokenInjector{})
+	ctx := context.Background()
+
+	config := map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 8080}, "Hosts": []any{"a", "b"}}
+	keys, err := client.UpdateValuesByKey(ctx, "svc", true, requests.UpdateKeysRequest{Value: config})
+	require.NoError(t, err)
+	assert.Len(t, keys.Response, 4)
+	_, err = client.UpdateValuesByKey(ctx, "svcx", false, requests.UpdateKeysRequest{Value: "other"})
+	require.NoError(t, err)
+
+	// the flattened values are stored as strings, the keys are matched by their raw prefix like Core Keeper does
+	values, err := client.ValuesByKey(ctx, "svc")
+	require.NoError(t, err)
+	require.Len(t, values.Response, 5)
+	assert.Equal(t, "svcx", values.Response[4].Key)
+	assert.Equal(t, "svc/Hosts/0", values.Response[0].Key)
+	assert.Equal(t, "a", values.Response[0].Value)
+	assert.Equal(t, "8080", values.Response[3].Value)
+	assert.NotZero(t, values.Response[3].Created)
+
+	keyList, err := client.ListKeys(ctx, "svc/Writable")
+	require.NoError(t, err)
+	assert.Equal(t, []models.KeyOnly{"svc/Writable/LogLevel", "svc/Writable/Port"}, keyList.Response)
+
+	// an update keeps the creation time
+	created := values.Response[3].Created
+	time.Sleep(2 * time.Millisecond)
+	_, err = client.UpdateValuesByKey(ctx, "svc/Writable/Port", false, requests.UpdateKeysRequest{Value: "9090"})
+	require.NoError(t, err)
+	kv, found := keeper.Get("svc/Writable/Port")
+	require.True(t, found)
+	assert.Equal(t, created, kv.Created)
+	assert.Greater(t, kv.Modified, created)
+
+	_, err = client.ValuesByKey(ctx, "missing")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Code())
+
+	// only the exact key is deleted without prefix match
+	_, err = client.DeleteKey(ctx, "svc/Writable")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Code())
+	deleted, err := client.DeleteKeysByPrefix(ctx, "svc/Writable")
+	require.NoError(t, err)
+	assert.Equal(t, []models.KeyOnly{"svc/Writable/LogLevel", "svc/Writable/Port"}, deleted.Response)
+	_, err = client.DeleteKey(ctx, "svc/Hosts/0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"svc/Hosts/1", "svcx"}, keeper.Keys())
+
+	keeper.Reset()
+	assert.Empty(t, keeper.Keys())
+}
+
+func TestServerBadRequest(t *testing.T) {
+	server := NewServer().Start()
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodPut, server.URL+keyRoutePrefix+"svc", nil)
+	require.NoError(t, err)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	keeper := NewServer()
+	keeper.SetAuthToken("token")
+	server := keeper.Start()
+	defer server.Close()
+	ctx := context.Background()
+
+	_, err := httpClient.NewKVSClient(server.URL, tokenInjector{}).ValuesByKey(ctx, "svc")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Code())
+	_, err = httpClient.NewKVSClient(server.URL, tokenInjector{token: "wrong"}).ValuesByKey(ctx, "svc")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.Code())
+	_, err = httpClient.NewKVSClient(server.URL, tokenInjector{token: "token"}).ValuesByKey(ctx, "svc")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, err.Code())
+
+	// ping doesn't require authentication
+	_, err = httpClient.NewCommonClient(server.URL, tokenInjector{}).Ping(ctx)
+	require.NoError(t, err)
+}
+
+func TestServerPublish(t *testing.T) {
+	bus := NewMessageBus()
+	keeper := NewServer()
+	keeper.SetMessageClient(bus.NewClient())
+	server := keeper.Start()
+	defer server.Close()
+
+	subscriber := bus.NewClient()
+	messages := make(chan msgTypes.MessageEnvelope, 10)
+	require.NoError(t, subscriber.Subscribe([]msgTypes.TopicChannel{{Topic: TopicPrefix + "/svc/#", Messages: messages}}, nil))
+
+	client := httpClient.NewKVSClient(server.URL, tokenInjector{})
+	_, err := client.UpdateValuesByKey(context.Background(), "svc/Writable/LogLevel", false, requests.UpdateKeysRequest{Value: "DEBUG"})
+	require.NoError(t, err)
+
+	select {
+	case message := <-messages:
+		assert.Equal(t, TopicPrefix+"/svc/Writable/LogLevel", message.ReceivedTopic)
+		var kv models.KVS
+		require.NoError(t, json.Unmarshal(message.Payload.([]byte), &kv))
+		assert.Equal(t, "svc/Writable/LogLevel", kv.Key)
+		assert.Equal(t, "DEBUG", kv.Value)
+	case <-time.After(time.Second):
+		require.Fail(t, "no change published")
+	}
+}
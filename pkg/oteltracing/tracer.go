@@ -0,0 +1,81 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oteltracing bridges the spans of the configuration client to OpenTelemetry, so that only the services
+// tracing the client depend on it
+package oteltracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/edgexfoundry/go-mod-configuration/v4/pkg/types"
+)
+
+const tracerName = "github.com/edgexfoundry/go-mod-configuration/v4"
+
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer returns the types.Tracer creating the client spans with provider, whose trace context is propagated to
+// the Configuration service in the W3C trace context and baggage headers
+func NewTracer(provider trace.TracerProvider) types.Tracer {
+	return &tracer{
+		tracer:     provider.Tracer(tracerName),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+func (t *tracer) StartSpan(ctx context.Context, operation string, attributes map[string]any) (context.Context, types.Span) {
+	ctx, s := t.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(toAttributes(attributes)...))
+	return ctx, &span{span: s}
+}
+
+func (t *tracer) InjectHeaders(ctx context.Context, header http.Header) {
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s *span) SetAttributes(attributes map[string]any) {
+	s.span.SetAttributes(toAttributes(attributes)...)
+}
+
+func (s *span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// toAttributes converts the attributes of the client spans, the values which aren't strings, ints or bools are
+// formatted as strings
+func toAttributes(attributes map[string]any) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for key, value := range attributes {
+		switch v := value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(key, v))
+		default:
+			kvs = append(kvs, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
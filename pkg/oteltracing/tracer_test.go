@@ -0,0 +1,49 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package oteltracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := NewTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, span := tracer.StartSpan(context.Background(), "GetConfiguration", map[string]any{"config.key": "core-data"})
+	span.SetAttributes(map[string]any{"config.coalesced": 2, "config.stale": true, "config.interval": time.Second})
+	header := http.Header{}
+	tracer.InjectHeaders(ctx, header)
+	span.End(errors.New("not found"))
+
+	require.Len(t, recorder.Ended(), 1)
+	ended := recorder.Ended()[0]
+	assert.Equal(t, "GetConfiguration", ended.Name())
+	assert.Equal(t, trace.SpanKindClient, ended.SpanKind())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("config.key", "core-data"),
+		attribute.Int("config.coalesced", 2),
+		attribute.Bool("config.stale", true),
+		attribute.String("config.interval", "1s"),
+	}, ended.Attributes())
+	assert.Equal(t, codes.Error, ended.Status().Code)
+	assert.Equal(t, "not found", ended.Status().Description)
+	assert.NotEmpty(t, ended.Events(), "the error should be recorded")
+	assert.Equal(t, fmt.Sprintf("00-%s-%s-01", ended.SpanContext().TraceID(), ended.SpanContext().SpanID()), header.Get("traceparent"))
+}
@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditOperation is the kind of configuration change recorded in the audit trail
+type AuditOperation string
+
+// The audited operations
+const (
+	AuditPut    AuditOperation = "Put"
+	AuditDelete AuditOperation = "Delete"
+)
+
+// AuditEvent records a configuration value written or deleted through the client.
+// The values of the sensitive keys and the encrypted values are redacted.
+type AuditEvent struct {
+	// Time is when the change was made
+	Time time.Time `json:"time"`
+	// Actor is the identity of whom made the change, as given by the AuthInjector, or empty if not known
+	Actor string `json:"actor,omitempty"`
+	// Operation is the kind of change
+	Operation AuditOperation `json:"operation"`
+	// Key is the full path of the changed key
+	Key string `json:"key"`
+	// OldValue is the value before the change, or nil if the key didn't exist
+	OldValue *string `json:"oldValue,omitempty"`
+	// NewValue is the value after the change, or nil if the key was deleted
+	NewValue *string `json:"newValue,omitempty"`
+}
+
+// Auditor records the configuration changes made through the client, once they succeeded.
+// A change isn't reverted if it can't be recorded.
+type Auditor interface {
+	Audit(event AuditEvent) error
+}
+
+// ActorIdentifier is implemented by the AuthInjectors which know the identity of the actor their requests are made
+// for. The subject of the bearer JWT added by the other AuthInjectors is used instead.
+type ActorIdentifier interface {
+	ActorIdentity() string
+}
+
+// FileAuditor is an Auditor appending the events to a file as JSON lines, which is safe for concurrent use
+type FileAuditor struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// NewFileAuditor opens the file at path to append the events to, creating it if needed.
+// The FileAuditor must be closed once the client isn't used anymore.
+func NewFileAuditor(path string) (*FileAuditor, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the audit file: %w", err)
+	}
+	return &FileAuditor{file: file}, nil
+}
+
+// Audit appends event to the file as a JSON line
+func (a *FileAuditor) Audit(event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	_, err = a.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the file
+func (a *FileAuditor) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.file.Close()
+}
@@ -0,0 +1,21 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// CacheStatus describes where the configuration returned by GetConfiguration came from
+type CacheStatus struct {
+	// Enabled indicates if the local cache is configured, see CacheDirOption
+	Enabled bool
+	// Stale indicates if the last configuration returned was served from the local cache
+	// because the Configuration service was unreachable
+	Stale bool
+	// CachedAt is the time the cached configuration was retrieved from the Configuration service
+	CachedAt time.Time
+	// LastError is the error which caused the fallback to the local cache
+	LastError string
+}
@@ -42,6 +42,23 @@ type ServiceConfig struct {
 	BasePath string
 	// AuthInjector is an interface to obtain a JWT and secure transport for remote service calls
 	AuthInjector interfaces.AuthenticationInjector
+	// KeyProvider supplies the keys to encrypt the sensitive configuration values at rest. Encryption is disabled if not set.
+	KeyProvider KeyProvider
+	// SensitiveKeys are the key patterns, relative to BasePath, of the values to encrypt at rest, i.e. "Database/Password"
+	// or "Clients/*/Token". See path.Match for the pattern syntax. Struct fields tagged `config:"sensitive"` are
+	// always encrypted when put with PutConfiguration.
+	SensitiveKeys []string
+	// Metrics receives the measurements of the client operations and of the watches. Instrumentation is disabled if not set.
+	Metrics MetricsRecorder
+	// Tracer creates the spans of the client operations and of the watch-triggered re-fetches, whose trace context is
+	// propagated to the Configuration service. Tracing is disabled if not set.
+	Tracer Tracer
+	// Logger receives the debug and trace logs of the requests to the Configuration service and of the watches.
+	// The values of SensitiveKeys and the encrypted values are redacted. Logging is disabled if not set.
+	Logger Logger
+	// Auditor records the configuration values written and deleted through the client, with the identity of the actor
+	// given by AuthInjector, i.e. a FileAuditor. Auditing is disabled if not set.
+	Auditor Auditor
 	// Optional contains all other properties of the configuration provider might use.
 	// For example, it might need the message bus connection information to publish the config changes.
 	Optional map[string]any
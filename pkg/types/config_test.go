@@ -17,7 +17,12 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,3 +137,169 @@ func TestPopulateFromUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	keys := map[string][]byte{"key1": []byte("0123456789abcdef"), "key2": []byte("fedcba9876543210")}
+
+	provider, err := NewStaticKeyProvider("key2", keys)
+	require.NoError(t, err)
+	id, key, err := provider.CurrentKey()
+	require.NoError(t, err)
+	assert.Equal(t, "key2", id)
+	assert.Equal(t, keys["key2"], key)
+
+	key, err = provider.Key("key1")
+	require.NoError(t, err)
+	assert.Equal(t, keys["key1"], key)
+	_, err = provider.Key("key3")
+	assert.Error(t, err)
+
+	_, err = NewStaticKeyProvider("key3", keys)
+	assert.Error(t, err)
+	_, err = NewStaticKeyProvider("bad:id", map[string][]byte{"bad:id": keys["key1"]})
+	assert.Error(t, err)
+}
+
+func TestGetOptionalDuration(t *testing.T) {
+	target := ServiceConfig{
+		Optional: map[string]any{
+			"String":   "500ms",
+			"Duration": 2 * time.Second,
+			"Invalid":  "bogus",
+		},
+	}
+
+	actual, err := target.GetOptionalDuration("String", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, actual)
+
+	actual, err = target.GetOptionalDuration("Duration", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, actual)
+
+	actual, err = target.GetOptionalDuration("Missing", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, actual)
+
+	_, err = target.GetOptionalDuration("Invalid", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestGetOptionalBool(t *testing.T) {
+	target := ServiceConfig{
+		Optional: map[string]any{
+			"String":  "true",
+			"Bool":    true,
+			"Invalid": "bogus",
+		},
+	}
+
+	for _, key := range []string{"String", "Bool"} {
+		actual, err := target.GetOptionalBool(key, false)
+		require.NoError(t, err, key)
+		assert.True(t, actual, key)
+	}
+
+	actual, err := target.GetOptionalBool("Missing", true)
+	require.NoError(t, err)
+	assert.True(t, actual)
+
+	_, err = target.GetOptionalBool("Invalid", false)
+	assert.Error(t, err)
+}
+
+func TestGetOptionalIntSlice(t *testing.T) {
+	target := ServiceConfig{
+		Optional: map[string]any{
+			"String":  "502, 503",
+			"Slice":   []int{502, 503},
+			"Any":     []any{"502", 503},
+			"Invalid": "502,bogus",
+		},
+	}
+
+	for _, key := range []string{"String", "Slice", "Any"} {
+		actual, err := target.GetOptionalIntSlice(key, nil)
+		require.NoError(t, err, key)
+		assert.Equal(t, []int{502, 503}, actual, key)
+	}
+
+	actual, err := target.GetOptionalIntSlice("Missing", []int{504})
+	require.NoError(t, err)
+	assert.Equal(t, []int{504}, actual)
+
+	_, err = target.GetOptionalIntSlice("Invalid", nil)
+	assert.Error(t, err)
+}
+
+func TestOperationError(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("wrapped: %w", &OperationError{
+		Kind:    ErrUnavailable,
+		KeyPath: "edgex/v4/core-data/Writable",
+		Code:    503,
+		Message: "unable to get value",
+		Err:     cause,
+	})
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "wrapped: unable to get value: connection refused", err.Error())
+
+	decodeErr := fmt.Errorf("wrapped: %w", &DecodeError{KeyPath: "edgex/v4/core-data/Port", Err: cause})
+	assert.ErrorIs(t, decodeErr, ErrDecode)
+	assert.ErrorIs(t, decodeErr, cause)
+	assert.Equal(t, "wrapped: failed to decode the configuration at edgex/v4/core-data/Port: connection refused", decodeErr.Error())
+}
+
+func TestConfigurationEntryRevision(t *testing.T) {
+	assert.Zero(t, ConfigurationEntry{}.Revision())
+	modified := time.UnixMilli(1735689600123)
+	assert.Equal(t, int64(1735689600123), ConfigurationEntry{Modified: modified}.Revision())
+}
+
+func TestErrorKind(t *testing.T) {
+	assert.Empty(t, ErrorKind(nil))
+	assert.Equal(t, ErrorKindNotFound, ErrorKind(&OperationError{Kind: ErrNotFound}))
+	assert.Equal(t, ErrorKindDecode, ErrorKind(fmt.Errorf("wrapped: %w", &DecodeError{})))
+	assert.Equal(t, ErrorKindConflict, ErrorKind(&OperationError{Kind: ErrConflict}))
+	rejected := &RejectedChangeError{KeyPath: "edgex/v4/core-data/Writable", Err: errors.New("invalid log level")}
+	assert.Equal(t, ErrorKindRejected, ErrorKind(rejected))
+	assert.EqualError(t, rejected, "the configuration change at edgex/v4/core-data/Writable was rejected: invalid log level")
+	rejected.Reverted = true
+	assert.EqualError(t, rejected, "the configuration change at edgex/v4/core-data/Writable was rejected and reverted: invalid log level")
+	assert.Equal(t, ErrorKindOther, ErrorKind(errors.New("failed")))
+
+	assert.NoError(t, HealthStatus{Healthy: true}.Err())
+	assert.Equal(t, ErrorKindUnauthorized, ErrorKind(HealthStatus{Auth: AuthStatusForbidden}.Err()))
+	assert.Equal(t, ErrorKindUnavailable, ErrorKind(HealthStatus{Auth: AuthStatusUnknown, Error: "connection refused"}.Err()))
+}
+
+func TestFileAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditor, err := NewFileAuditor(path)
+	require.NoError(t, err)
+
+	oldValue, newValue := "INFO", "DEBUG"
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, auditor.Audit(AuditEvent{Time: at, Actor: "core-command", Operation: AuditPut, Key: "edgex/v4/core-data/Writable/LogLevel", OldValue: &oldValue, NewValue: &newValue}))
+	require.NoError(t, auditor.Audit(AuditEvent{Time: at, Operation: AuditDelete, Key: "edgex/v4/core-data/Writable/LogLevel", OldValue: &newValue}))
+	require.NoError(t, auditor.Close())
+
+	// the events are appended to the existing file
+	auditor, err = NewFileAuditor(path)
+	require.NoError(t, err)
+	require.NoError(t, auditor.Audit(AuditEvent{Time: at, Operation: AuditPut, Key: "edgex/v4/core-data/Port", NewValue: &newValue}))
+	require.NoError(t, auditor.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"2025-01-01T00:00:00Z","actor":"core-command","operation":"Put","key":"edgex/v4/core-data/Writable/LogLevel","oldValue":"INFO","newValue":"DEBUG"}
+{"time":"2025-01-01T00:00:00Z","operation":"Delete","key":"edgex/v4/core-data/Writable/LogLevel","oldValue":"DEBUG"}
+{"time":"2025-01-01T00:00:00Z","operation":"Put","key":"edgex/v4/core-data/Port","newValue":"DEBUG"}
+`, string(content))
+
+	_, err = NewFileAuditor(filepath.Join(t.TempDir(), "missing", "audit.log"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyProvider supplies the AES keys used to encrypt the sensitive configuration values at rest.
+// Keys must be 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+type KeyProvider interface {
+	// CurrentKey returns the id and the key used to encrypt new values
+	CurrentKey() (string, []byte, error)
+	// Key returns the key with the specified id, used to decrypt values encrypted with previous keys
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider holding a fixed set of keys in memory
+type StaticKeyProvider struct {
+	currentId string
+	keys      map[string][]byte
+}
+
+// NewStaticKeyProvider creates a StaticKeyProvider which encrypts with the key of currentId.
+// The remaining keys are only used to decrypt values which were encrypted before a key rotation.
+func NewStaticKeyProvider(currentId string, keys map[string][]byte) (*StaticKeyProvider, error) {
+	if _, ok := keys[currentId]; !ok {
+		return nil, fmt.Errorf("current key id '%s' not found in the provided keys", currentId)
+	}
+	for id := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid key id '%s': key id must be non-empty and must not contain ':'", id)
+		}
+	}
+
+	return &StaticKeyProvider{
+		currentId: currentId,
+		keys:      keys,
+	}, nil
+}
+
+// CurrentKey returns the id and the key used to encrypt new values
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	return p.currentId, p.keys[p.currentId], nil
+}
+
+// Key returns the key with the specified id
+func (p *StaticKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("encryption key '%s' not found", id)
+	}
+	return key, nil
+}
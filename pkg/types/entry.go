@@ -0,0 +1,57 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// ConfigurationEntry is a configuration value along with the metadata of its revision
+type ConfigurationEntry struct {
+	// Key is the full path of the key
+	Key string
+	// Value is the value, decrypted if it's stored encrypted unless read as stored
+	Value []byte
+	// Encrypted reports whether the value is stored encrypted
+	Encrypted bool
+	// Created is when the key was created, or the zero time if not known
+	Created time.Time
+	// Modified is when the value was last written, or the zero time if not known
+	Modified time.Time
+}
+
+// Revision returns the revision of the value, i.e. its modification time in milliseconds since the epoch,
+// or 0 if not known. Two writes within the same millisecond have the same revision.
+func (e ConfigurationEntry) Revision() int64 {
+	if e.Modified.IsZero() {
+		return 0
+	}
+	return e.Modified.UnixMilli()
+}
+
+// Precondition is the state the current value must be in for a conditional write to proceed
+type Precondition struct {
+	// Value is the expected current value, which isn't checked if nil
+	Value []byte
+	// Revision is the expected revision of the current value, as returned by ConfigurationEntry.Revision,
+	// which isn't checked if 0
+	Revision int64
+	// Absent requires the key not to exist, i.e. to create a value only once
+	Absent bool
+}
+
+// ExpectValue returns the Precondition of a current value equal to value
+func ExpectValue(value []byte) Precondition {
+	return Precondition{Value: value}
+}
+
+// ExpectRevision returns the Precondition of a current value at revision
+func ExpectRevision(revision int64) Precondition {
+	return Precondition{Revision: revision}
+}
+
+// ExpectAbsent returns the Precondition of a key which doesn't exist
+func ExpectAbsent() Precondition {
+	return Precondition{Absent: true}
+}
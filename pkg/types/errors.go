@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The categories of the errors returned by the configuration clients, to be checked with errors.Is
+var (
+	// ErrNotFound means the requested configuration or key doesn't exist
+	ErrNotFound = errors.New("configuration not found")
+	// ErrUnauthorized means the Configuration service rejected the client's credentials or denied the access
+	ErrUnauthorized = errors.New("unauthorized access to the Configuration service")
+	// ErrUnavailable means the Configuration service is unreachable or temporarily unable to handle the request
+	ErrUnavailable = errors.New("the Configuration service is unavailable")
+	// ErrDecode means the stored configuration couldn't be decoded, see DecodeError for the offending key
+	ErrDecode = errors.New("configuration decoding failed")
+	// ErrConflict means the configuration value changed since it was read, so it wasn't written
+	ErrConflict = errors.New("configuration value changed concurrently")
+	// ErrRejected means a watched configuration change was refused by its ConfigurationValidator or by the handler of
+	// the watch, see RejectedChangeError
+	ErrRejected = errors.New("configuration change rejected")
+)
+
+// The names of the error categories returned by ErrorKind
+const (
+	ErrorKindNotFound     = "NotFound"
+	ErrorKindUnauthorized = "Unauthorized"
+	ErrorKindUnavailable  = "Unavailable"
+	ErrorKindDecode       = "Decode"
+	ErrorKindConflict     = "Conflict"
+	ErrorKindRejected     = "Rejected"
+	ErrorKindOther        = "Other"
+)
+
+// ErrorKind returns the name of the category of err, ErrorKindOther if it isn't categorized, or "" if err is nil
+func ErrorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotFound):
+		return ErrorKindNotFound
+	case errors.Is(err, ErrUnauthorized):
+		return ErrorKindUnauthorized
+	case errors.Is(err, ErrUnavailable):
+		return ErrorKindUnavailable
+	case errors.Is(err, ErrDecode):
+		return ErrorKindDecode
+	case errors.Is(err, ErrConflict):
+		return ErrorKindConflict
+	case errors.Is(err, ErrRejected):
+		return ErrorKindRejected
+	default:
+		return ErrorKindOther
+	}
+}
+
+// OperationError is the error of a failed request to the Configuration service.
+// errors.Is matches Kind, and errors.As reaches Err, i.e. the errors.EdgeX error from core-contracts.
+type OperationError struct {
+	// Kind is one of ErrNotFound, ErrUnauthorized, ErrUnavailable or ErrConflict, or nil if the failure isn't categorized
+	Kind error
+	// KeyPath is the full path of the key the request was for
+	KeyPath string
+	// Code is the status code of the failed request, or 0 if not known
+	Code int
+	// Message describes the failed operation
+	Message string
+	// Err is the underlying error
+	Err error
+}
+
+func (e *OperationError) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *OperationError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// DecodeError is the error of configuration values which couldn't be decoded, errors.Is matches ErrDecode
+type DecodeError struct {
+	// KeyPath is the full path of the offending key or key prefix
+	KeyPath string
+	// Err is the underlying error
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode the configuration at %s: %v", e.KeyPath, e.Err)
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// RejectedChangeError is the error of a watched configuration change refused by the ConfigurationValidator of the
+// configuration, or by the handler of the watch, errors.Is matches ErrRejected
+type RejectedChangeError struct {
+	// KeyPath is the full path of the key prefix whose configuration was changed
+	KeyPath string
+	// Reverted is true if the changed keys were reverted to their previous values in the Configuration service
+	Reverted bool
+	// Err is the error returned by the ConfigurationValidator or by the handler
+	Err error
+}
+
+func (e *RejectedChangeError) Error() string {
+	if e.Reverted {
+		return fmt.Sprintf("the configuration change at %s was rejected and reverted: %v", e.KeyPath, e.Err)
+	}
+	return fmt.Sprintf("the configuration change at %s was rejected: %v", e.KeyPath, e.Err)
+}
+
+func (e *RejectedChangeError) Is(target error) bool {
+	return target == ErrRejected
+}
+
+func (e *RejectedChangeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,71 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuthStatus is the outcome of an authenticated request to the Configuration service
+type AuthStatus string
+
+const (
+	// AuthStatusUnknown means the authentication couldn't be checked, i.e. the Configuration service is unreachable
+	AuthStatusUnknown AuthStatus = "Unknown"
+	// AuthStatusOK means the authenticated request was accepted
+	AuthStatusOK AuthStatus = "OK"
+	// AuthStatusUnauthorized means the credentials were missing or rejected
+	AuthStatusUnauthorized AuthStatus = "Unauthorized"
+	// AuthStatusForbidden means the credentials were accepted but don't grant access to the service's configuration
+	AuthStatusForbidden AuthStatus = "Forbidden"
+)
+
+// HealthStatus is the diagnostics report of the connection to the Configuration service
+type HealthStatus struct {
+	// Healthy indicates if the Configuration service is reachable and accepts the client's credentials
+	Healthy bool
+	// Url is the URL of the Configuration service
+	Url string
+	// Latency is the round trip time of the ping request
+	Latency time.Duration
+	// ServiceName is the name reported by the Configuration service in the ping response
+	ServiceName string
+	// ApiVersion is the API version reported by the Configuration service in the ping response
+	ApiVersion string
+	// Error is the error of the failed ping request
+	Error string
+	// ErrorKind is the category of Error, i.e. ServiceUnavailable, Unauthorized or UnexpectedServerError
+	ErrorKind string
+	// StatusCode is the HTTP status code of the failed ping request
+	StatusCode int
+	// Auth is the outcome of an authenticated request for the service's configuration
+	Auth AuthStatus
+	// LastSuccessfulRead is the time of the last successful read from the Configuration service
+	LastSuccessfulRead time.Time
+	// LastSuccessfulWrite is the time of the last successful write into the Configuration service
+	LastSuccessfulWrite time.Time
+	// ActiveWatches is the number of running WatchForChanges
+	ActiveWatches int
+	// LastWatchError is the last error reported by WatchForChanges
+	LastWatchError string
+	// LastWatchErrorTime is the time of LastWatchError
+	LastWatchErrorTime time.Time
+}
+
+// Err returns nil if the status is healthy, otherwise an error matching ErrUnauthorized or ErrUnavailable
+func (s HealthStatus) Err() error {
+	switch {
+	case s.Healthy:
+		return nil
+	case s.Auth == AuthStatusUnauthorized || s.Auth == AuthStatusForbidden:
+		return fmt.Errorf("%w: %s", ErrUnauthorized, s.Auth)
+	case s.Error != "":
+		return fmt.Errorf("%w: %s", ErrUnavailable, s.Error)
+	default:
+		return ErrUnavailable
+	}
+}
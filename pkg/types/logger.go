@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// Logger is the structured logger of the configuration client, where args are alternating keys and values.
+// It is satisfied by both the core-contracts logger.LoggingClient and *slog.Logger. The trace logs are emitted with
+// Trace if the logger has such a method, like LoggingClient, at slog level -8 for *slog.Logger, or with Debug otherwise.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
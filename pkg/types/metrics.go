@@ -0,0 +1,36 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// WatchOutcome is what happened to a configuration change message received by WatchForChanges
+type WatchOutcome string
+
+const (
+	// WatchMessageReceived is counted for every message received from the message bus
+	WatchMessageReceived WatchOutcome = "received"
+	// WatchMessageSkipped is counted for the messages which are outdated by a newer value in the Configuration service,
+	// or which are about a key not watched
+	WatchMessageSkipped WatchOutcome = "skipped"
+	// WatchMessageDecoded is counted for the messages whose configuration was decoded and sent on the update channel
+	WatchMessageDecoded WatchOutcome = "decoded"
+	// WatchMessageFailed is counted for the messages which caused an error to be sent on the error channel
+	WatchMessageFailed WatchOutcome = "failed"
+)
+
+// WatchDecodeOperation is the operation observed for the re-fetch and decode of the configuration after a change message
+const WatchDecodeOperation = "WatchDecode"
+
+// MetricsRecorder receives the measurements of the configuration client. It is implemented by the caller to bridge
+// the measurements to its metrics library, i.e. go-metrics or Prometheus, and must be safe for concurrent use.
+type MetricsRecorder interface {
+	// ObserveOperation records a call of the Client method named operation, or WatchDecodeOperation, which took duration.
+	// errorKind is empty if the call succeeded, otherwise it is the category returned by ErrorKind.
+	ObserveOperation(operation string, duration time.Duration, errorKind string)
+	// IncWatchMessages counts a configuration change message received by WatchForChanges with the given outcome
+	IncWatchMessages(outcome WatchOutcome)
+}
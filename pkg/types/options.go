@@ -0,0 +1,184 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// The keys of the ServiceConfig.Optional properties understood by the configuration clients
+const (
+	// CacheDirOption is the directory in which the last good configuration is cached on disk.
+	// The local cache is disabled if not set.
+	CacheDirOption = "CacheDir"
+	// CacheRefreshIntervalOption is how often the Configuration service is checked for reachability while the
+	// configuration is served from the local cache, i.e. "10s", which must be positive
+	CacheRefreshIntervalOption = "CacheRefreshInterval"
+
+	// RetryAttemptsOption is the maximum number of attempts of each request to the Configuration service,
+	// including the first one. Requests are not retried if not set.
+	RetryAttemptsOption = "RetryAttempts"
+	// RetryInitialBackoffOption is the wait before the first retry, which doubles for every further retry, i.e. "100ms"
+	RetryInitialBackoffOption = "RetryInitialBackoff"
+	// RetryMaxBackoffOption caps the wait between two retries, i.e. "5s"
+	RetryMaxBackoffOption = "RetryMaxBackoff"
+	// RetryJitterOption is the fraction, between 0 and 1, of each wait which is randomized to spread the retries
+	RetryJitterOption = "RetryJitter"
+	// RetryStatusCodesOption lists the HTTP status codes of the failed requests to retry, i.e. "502,503,504"
+	RetryStatusCodesOption = "RetryStatusCodes"
+	// CircuitBreakerThresholdOption is the number of consecutive failed requests after which the circuit breaker opens
+	// and fails all requests without sending them. The circuit breaker is disabled if not set.
+	CircuitBreakerThresholdOption = "CircuitBreakerThreshold"
+	// CircuitBreakerOpenTimeoutOption is how long the circuit breaker stays open before letting a trial request through, i.e. "30s"
+	CircuitBreakerOpenTimeoutOption = "CircuitBreakerOpenTimeout"
+
+	// WatchDebounceOption is the window, starting at the first change message received by WatchForChanges, in which the
+	// further change messages are coalesced into one re-fetch and one update of the configuration, i.e. "200ms".
+	// Every change message is processed on its own if not set.
+	WatchDebounceOption = "WatchDebounce"
+	// WatchDeliveryPolicyOption is the WatchDeliveryPolicy of the updates and the errors sent by WatchForChanges,
+	// WatchDeliveryBlock if not set
+	WatchDeliveryPolicyOption = "WatchDeliveryPolicy"
+	// WatchDeliveryBufferOption is the number of updates, and of errors, held by the WatchDeliveryDropOldest policy
+	// while the consumer is busy
+	WatchDeliveryBufferOption = "WatchDeliveryBuffer"
+	// WatchResubscribeOption enables the recovery of the watches whose message bus subscription is lost, i.e. "true".
+	// Once the message bus reports an error, the watch subscribes again with the message client returned by
+	// getMsgClientCb, and re-fetches the whole configuration to catch up with the changes missed meanwhile.
+	WatchResubscribeOption = "WatchResubscribe"
+	// WatchResubscribeInitialBackoffOption is the wait before the first attempt to subscribe again, which doubles for
+	// every further attempt, i.e. "1s"
+	WatchResubscribeInitialBackoffOption = "WatchResubscribeInitialBackoff"
+	// WatchResubscribeMaxBackoffOption caps the wait between two attempts to subscribe again, i.e. "30s"
+	WatchResubscribeMaxBackoffOption = "WatchResubscribeMaxBackoff"
+	// WatchRevertRejectedOption enables reverting the keys of a configuration change rejected by the
+	// ConfigurationValidator of the watched configuration to their previous values in the Configuration service, i.e. "true"
+	WatchRevertRejectedOption = "WatchRevertRejected"
+)
+
+const (
+	DefaultCacheRefreshInterval      = 10 * time.Second
+	DefaultRetryInitialBackoff       = 100 * time.Millisecond
+	DefaultRetryMaxBackoff           = 5 * time.Second
+	DefaultRetryJitter               = 0.2
+	DefaultCircuitBreakerOpenTimeout = 30 * time.Second
+	DefaultWatchDeliveryBuffer       = 16
+
+	DefaultWatchResubscribeInitialBackoff = time.Second
+	DefaultWatchResubscribeMaxBackoff     = 30 * time.Second
+)
+
+// WatchDeliveryPolicy defines what WatchForChanges does with the updates and the errors while the consumer of its
+// channels is busy. The watch can be stopped with any policy, even while a send is pending.
+// With the policies other than WatchDeliveryBlock, each update is a new value holding the updated configuration, as the
+// configuration passed to the watch is updated again by the next changes while the update waits for the consumer.
+type WatchDeliveryPolicy string
+
+const (
+	// WatchDeliveryBlock waits for the consumer, and stops processing the configuration changes meanwhile
+	WatchDeliveryBlock WatchDeliveryPolicy = "Block"
+	// WatchDeliveryDropOldest holds up to WatchDeliveryBufferOption updates, and errors, for the consumer,
+	// and drops the oldest one once full
+	WatchDeliveryDropOldest WatchDeliveryPolicy = "DropOldest"
+	// WatchDeliveryKeepLatest holds only the latest update, and error, for the consumer,
+	// which replaces the previous one not received yet
+	WatchDeliveryKeepLatest WatchDeliveryPolicy = "KeepLatest"
+)
+
+// DefaultRetryStatusCodes are the status codes of the transient failures, 503 is also used for connection errors
+var DefaultRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// GetOptionalString returns the string value of the Optional property with the given key, or defaultValue if not set
+func (config ServiceConfig) GetOptionalString(key string, defaultValue string) (string, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	str, err := cast.ToStringE(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return str, nil
+}
+
+// GetOptionalDuration returns the duration value of the Optional property with the given key, or defaultValue if not set.
+// The value may be a duration string such as "500ms" or a time.Duration.
+func (config ServiceConfig) GetOptionalDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	duration, err := cast.ToDurationE(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return duration, nil
+}
+
+// GetOptionalInt returns the int value of the Optional property with the given key, or defaultValue if not set
+func (config ServiceConfig) GetOptionalInt(key string, defaultValue int) (int, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	i, err := cast.ToIntE(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return i, nil
+}
+
+// GetOptionalFloat returns the float value of the Optional property with the given key, or defaultValue if not set
+func (config ServiceConfig) GetOptionalFloat(key string, defaultValue float64) (float64, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	f, err := cast.ToFloat64E(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return f, nil
+}
+
+// GetOptionalBool returns the bool value of the Optional property with the given key, or defaultValue if not set
+func (config ServiceConfig) GetOptionalBool(key string, defaultValue bool) (bool, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	b, err := cast.ToBoolE(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return b, nil
+}
+
+// GetOptionalIntSlice returns the int values of the Optional property with the given key, or defaultValue if not set.
+// The value may be a comma separated string such as "502,503" or a slice.
+func (config ServiceConfig) GetOptionalIntSlice(key string, defaultValue []int) ([]int, error) {
+	value, ok := config.Optional[key]
+	if !ok || value == nil {
+		return defaultValue, nil
+	}
+	if str, isString := value.(string); isString {
+		items := strings.Split(str, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		value = items
+	}
+	result, err := cast.ToIntSliceE(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for optional property %s: %v", key, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const DefaultReadinessInterval = time.Second
+
+// ReadinessPolicy defines what WaitUntilReady waits for
+type ReadinessPolicy struct {
+	// Interval is the wait between two readiness checks, DefaultReadinessInterval is used if not set
+	Interval time.Duration
+	// WaitForConfiguration also waits until the Configuration service contains the service's configuration,
+	// i.e. for a service whose configuration is seeded by another service
+	WaitForConfiguration bool
+}
+
+// ReadinessError is returned by WaitUntilReady when the context expires before the Configuration service is ready.
+// It describes the outcome of the last readiness check.
+type ReadinessError struct {
+	// Url is the URL of the Configuration service
+	Url string
+	// BasePath is the path of the service's configuration
+	BasePath string
+	// Attempts is the number of readiness checks done
+	Attempts int
+	// Elapsed is the time spent waiting
+	Elapsed time.Duration
+	// Reachable indicates if the last ping of the Configuration service succeeded
+	Reachable bool
+	// PingError is the error of the last failed ping
+	PingError string
+	// ConfigurationMissing indicates if the service's configuration was not found by the last check
+	ConfigurationMissing bool
+	// ConfigurationError is the error of the last failed configuration existence check
+	ConfigurationError string
+	// Cause is the error of the expired context
+	Cause error
+}
+
+func (e *ReadinessError) Error() string {
+	var reason string
+	switch {
+	case !e.Reachable:
+		reason = fmt.Sprintf("unable to ping the Configuration service: %s", e.PingError)
+	case e.ConfigurationError != "":
+		reason = fmt.Sprintf("unable to check the configuration existence for %s: %s", e.BasePath, e.ConfigurationError)
+	case e.ConfigurationMissing:
+		reason = fmt.Sprintf("the configuration for %s doesn't exist yet", e.BasePath)
+	default:
+		reason = "readiness was not checked"
+	}
+	return fmt.Sprintf("the Configuration service at %s is not ready after %d attempt(s) in %s: %s (%v)",
+		e.Url, e.Attempts, e.Elapsed.Round(time.Millisecond), reason, e.Cause)
+}
+
+func (e *ReadinessError) Unwrap() error {
+	return e.Cause
+}
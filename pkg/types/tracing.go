@@ -0,0 +1,30 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer creates the spans of the configuration client. It is implemented by the caller to bridge the spans to its
+// tracing library, i.e. with oteltracing.NewTracer for OpenTelemetry, and must be safe for concurrent use.
+type Tracer interface {
+	// StartSpan starts the span of the client operation, as a child of the span of ctx if any, with attributes whose
+	// values are strings, ints or bools. Returns the context holding the new span along with the span.
+	StartSpan(ctx context.Context, operation string, attributes map[string]any) (context.Context, Span)
+	// InjectHeaders puts the trace context of the span of ctx into the headers of a request sent to the
+	// Configuration service, i.e. the W3C trace context headers
+	InjectHeaders(ctx context.Context, header http.Header)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// SetAttributes adds attributes to the span, whose values are strings, ints or bools
+	SetAttributes(attributes map[string]any)
+	// End ends the span, recording err as the failure of the operation unless it's nil
+	End(err error)
+}
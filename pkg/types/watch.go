@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2025 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// WatchTarget is a key watched by WatchForMultipleChanges along with the configuration struct its values are decoded into
+type WatchTarget struct {
+	// WaitKey is the watched key relative to the base path, whose segments may be the "*" wildcard matching any single
+	// segment, i.e. "Clients/*" to watch each client separately
+	WaitKey string
+	// Configuration is the target struct the values under the watched key are decoded into. As it's decoded again for
+	// every update, and for every key matched by WaitKey, the consumer must copy it before the next update.
+	Configuration interface{}
+}
+
+// WatchUpdate is sent on the update channel by WatchForMultipleChanges once the configuration under a watched key is
+// updated
+type WatchUpdate struct {
+	// WaitKey is the WaitKey of the WatchTarget whose configuration was updated
+	WaitKey string
+	// Key is the full path of the updated key matched by WaitKey
+	Key string
+	// Configuration is the Configuration of the WatchTarget, decoded from the values under Key
+	Configuration interface{}
+}
+
+// ConfigurationValidator is implemented by the configuration structs passed to the watches to validate the
+// configuration decoded after a change, before it's applied. Returning an error rejects the change: the previous good
+// configuration is kept, and a *RejectedChangeError is sent on the error channel of the watch.
+type ConfigurationValidator interface {
+	Validate() error
+}